@@ -6,7 +6,9 @@ package rings
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"sort"
 
 	"gonum.org/v1/plot/vg"
 
@@ -28,13 +30,107 @@ type ArcOfer interface {
 	ArcOf(loc, f feat.Feature) (Arc, error)
 }
 
+// ArcsOfer is an ArcOfer that can find the arcs of a collection of features in a single pass.
+type ArcsOfer interface {
+	ArcOfer
+
+	// ArcsOf appends the arcs of the features in fs, each in the context of its
+	// location, to dst and returns the extended slice. The arc found for each
+	// feature must be the arc returned by ArcOf(f.Location(), f). ArcsOf must
+	// return a non-nil error if any feature is not found by the receiver.
+	ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error)
+}
+
+// arcsOf returns the arcs of the features in fs appended to dst. If base is an
+// ArcsOfer, its ArcsOf method is used, otherwise ArcOf is called for each feature.
+func arcsOf(base ArcOfer, dst []Arc, fs []feat.Feature) ([]Arc, error) {
+	if base, ok := base.(ArcsOfer); ok {
+		return base.ArcsOf(dst, fs)
+	}
+	for _, f := range fs {
+		arc, err := base.ArcOf(f.Location(), f)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, arc)
+	}
+	return dst, nil
+}
+
+// visibleArcsOf returns the arcs of the features in fs appended to dst as arcsOf does,
+// but skips features that base reports are outside its display windows. The features
+// that were not skipped are returned in vis, in order and aligned with the appended arcs.
+func visibleArcsOf(base ArcOfer, dst []Arc, fs []feat.Feature) (arcs []Arc, vis []feat.Feature, err error) {
+	n := len(dst)
+	arcs, err = arcsOf(base, dst, fs)
+	if err != ErrOutsideWindows {
+		return arcs, fs, err
+	}
+	arcs = dst[:n]
+	vis = make([]feat.Feature, 0, len(fs))
+	for _, f := range fs {
+		arc, err := base.ArcOf(f.Location(), f)
+		if err == ErrOutsideWindows {
+			continue
+		}
+		if err != nil {
+			return arcs, vis, err
+		}
+		arcs = append(arcs, arc)
+		vis = append(vis, f)
+	}
+	return arcs, vis, nil
+}
+
+// position is a zero length feature marking a position on a location.
+type position struct {
+	loc feat.Feature
+	pos int
+}
+
+func (p position) Start() int             { return p.pos }
+func (p position) End() int               { return p.pos }
+func (p position) Len() int               { return 0 }
+func (p position) Name() string           { return fmt.Sprintf("%s:%d", p.loc.Name(), p.pos) }
+func (p position) Description() string    { return "position" }
+func (p position) Location() feat.Feature { return p.loc }
+
+// angleOf returns the angle of the position pos on the feature loc as mapped by base.
+// Non-uniform mappings such as those of a SegmentedArcs are respected, and a non-nil
+// error is returned if base does not map the position.
+func angleOf(base ArcOfer, loc feat.Feature, pos int) (Angle, error) {
+	arc, err := base.ArcOf(loc, position{loc: loc, pos: pos})
+	if err != nil {
+		return angleNaN, err
+	}
+	return arc.Theta, nil
+}
+
+// PositionOfer is an ArcOfer that can map an angle back to the position of a feature
+// mapped to its span.
+type PositionOfer interface {
+	ArcOfer
+
+	// PositionOf returns the feature found at the angle theta and the position
+	// within the feature corresponding to theta, in the coordinate system of the
+	// feature's Start and End. PositionOf must return a non-nil error if no
+	// feature is found at theta.
+	PositionOf(theta Angle) (f feat.Feature, pos int, err error)
+}
+
 // Point represents a 2-D point.
 type Point struct {
 	X, Y float64
 }
 
 // Normalize returns the angle corresponding to theta in the range [0, 2*math.Pi).
-func Normalize(theta Angle) Angle { return Angle(math.Mod(float64(theta)+2*math.Pi, 2*math.Pi)) }
+func Normalize(theta Angle) Angle {
+	theta = Angle(math.Mod(float64(theta)+2*math.Pi, 2*math.Pi))
+	if theta < 0 {
+		theta += Complete
+	}
+	return theta
+}
 
 // Rectangular returns the rectangular coordinates for the location defined by theta and r
 // in polar coordinates.
@@ -45,6 +141,15 @@ func Rectangular(theta Angle, r vg.Length) vg.Point {
 	return vg.Point{X: vg.Length(math.Cos(float64(theta)) * float64(r)), Y: vg.Length(math.Sin(float64(theta)) * float64(r))}
 }
 
+// Spiral returns the rectangular coordinates for the location defined by theta and r
+// in the spiral coordinates of an Archimedean spiral with the specified origin angle.
+// The radius of the location is increased by pitch for each complete turn swept from
+// origin to theta.
+func Spiral(theta, origin Angle, r, pitch vg.Length) vg.Point {
+	turns := math.Abs(float64((theta - origin) / Complete))
+	return Rectangular(theta, r+pitch*vg.Length(turns))
+}
+
 // Polar returns the polar coordinates of a point.
 func Polar(p vg.Point) (theta Angle, r vg.Length) {
 	if (p == vg.Point{0, 0}) {
@@ -78,16 +183,236 @@ type Arc struct {
 func (a Arc) Arc() Arc { return a }
 
 // Contains returns a boolean indicating whether the parameter falls within the
-// arc described by the receiver.
+// arc described by the receiver. Arcs may cross angle zero and sweep in either
+// direction, and arcs sweeping a complete circle or more contain all angles.
 func (a Arc) Contains(alpha Angle) bool {
-	if a.Phi >= 0 {
-		a.Phi += a.Theta
-	} else {
-		a.Theta, a.Phi = a.Theta+a.Phi, a.Theta
+	_, ok := a.fraction(alpha)
+	return ok
+}
+
+// Intersect returns the arcs covered by both the receiver and b. Two arcs may intersect
+// in up to two separate arcs, and arcs that only touch intersect in an arc of zero sweep.
+// The returned arcs sweep in the direction of the receiver and are ordered along its sweep.
+func (a Arc) Intersect(b Arc) []Arc {
+	dir, l := a.span()
+	var is []interval
+	for _, iv := range a.intervalsOf(b) {
+		if iv.hi > l {
+			iv.hi = l
+		}
+		if iv.lo <= iv.hi {
+			is = append(is, iv)
+		}
+	}
+	return a.arcsAlong(dir, l, is)
+}
+
+// Union returns the arcs covered by either the receiver or b. The union of two arcs
+// is either a single arc or the two arcs if they do not overlap. The returned arcs sweep
+// in the direction of the receiver and are ordered along its sweep, with the arc holding
+// the receiver first. If the union covers a complete circle, a single complete arc
+// starting at the receiver's Theta is returned.
+func (a Arc) Union(b Arc) []Arc {
+	dir, l := a.span()
+	if l == Complete {
+		return []Arc{{Theta: a.Theta, Phi: dir * Complete}}
+	}
+	u := interval{0, l}
+	for _, iv := range a.intervalsOf(b) {
+		switch {
+		case iv.lo <= u.hi:
+			if iv.hi > u.hi {
+				u.hi = iv.hi
+			}
+		case iv.hi == Complete:
+			// The interval wraps around to meet the start of the receiver.
+			u.lo = iv.lo - Complete
+		default:
+			return []Arc{a.arcAlong(dir, u), a.arcAlong(dir, iv)}
+		}
+	}
+	if u.hi-u.lo >= Complete {
+		return []Arc{{Theta: a.Theta, Phi: dir * Complete}}
+	}
+	return []Arc{a.arcAlong(dir, u)}
+}
+
+// Subtract returns the arcs covered by the receiver but not by b. Removing an arc may
+// leave up to two separate arcs. The returned arcs sweep in the direction of the receiver
+// and are ordered along its sweep.
+func (a Arc) Subtract(b Arc) []Arc {
+	dir, l := a.span()
+	rest := []interval{{0, l}}
+	for _, iv := range a.intervalsOf(b) {
+		var next []interval
+		for _, r := range rest {
+			if iv.lo > r.lo {
+				lo := r
+				if iv.lo < lo.hi {
+					lo.hi = iv.lo
+				}
+				next = append(next, lo)
+			}
+			if iv.hi < r.hi {
+				hi := r
+				if iv.hi > hi.lo {
+					hi.lo = iv.hi
+				}
+				next = append(next, hi)
+			}
+		}
+		rest = next
+	}
+	return a.arcsAlong(dir, l, rest)
+}
+
+// Split returns the arcs obtained by splitting the receiver at each of the angles in
+// alpha that fall strictly within it. The returned arcs sweep in the direction of the
+// receiver and are ordered along its sweep.
+func (a Arc) Split(alpha ...Angle) []Arc {
+	dir, l := a.span()
+	offs := make([]float64, 0, len(alpha))
+	for _, t := range alpha {
+		if off := a.offset(t); off > 0 && off < l {
+			offs = append(offs, float64(off))
+		}
+	}
+	sort.Float64s(offs)
+
+	arcs := make([]Arc, 0, len(offs)+1)
+	var lo Angle
+	for _, off := range offs {
+		if Angle(off) == lo {
+			continue
+		}
+		arcs = append(arcs, a.arcAlong(dir, interval{lo, Angle(off)}))
+		lo = Angle(off)
+	}
+	return append(arcs, a.arcAlong(dir, interval{lo, l}))
+}
+
+// interval is a span of angular offsets along the sweep of an arc.
+type interval struct {
+	lo, hi Angle
+}
+
+// span returns the direction and the magnitude of the sweep of the arc, with the
+// magnitude limited to a complete circle.
+func (a Arc) span() (dir, l Angle) {
+	dir, l = CounterClockwise, a.Phi
+	if l < 0 {
+		dir, l = Clockwise, -l
+	}
+	if l > Complete {
+		l = Complete
+	}
+	return dir, l
+}
+
+// offset returns the angular distance from the start of the arc to alpha in the
+// direction of the arc's sweep, in the range [0, Complete).
+func (a Arc) offset(alpha Angle) Angle {
+	dir, _ := a.span()
+	return Normalize(dir * (alpha - a.Theta))
+}
+
+// intervalsOf returns the offsets along the sweep of the receiver covered by b, in ascending
+// order. An arc of b that crosses the start of the receiver is returned as two intervals.
+func (a Arc) intervalsOf(b Arc) []interval {
+	dir, _ := a.span()
+	bdir, l := b.span()
+	start := b.Theta
+	if bdir != dir {
+		start += b.Phi
 	}
-	alpha = Normalize(alpha)
+	if l == Complete {
+		return []interval{{0, Complete}}
+	}
+	lo := a.offset(start)
+	if lo+l <= Complete {
+		return []interval{{lo, lo + l}}
+	}
+	return []interval{{0, lo + l - Complete}, {lo, Complete}}
+}
+
+// arcsAlong returns the arcs described by the ordered intervals along the receiver, which
+// has the sweep direction dir and magnitude l. If the receiver is a complete circle,
+// intervals meeting at its start are joined.
+func (a Arc) arcsAlong(dir, l Angle, is []interval) []Arc {
+	if l == Complete && len(is) > 1 && is[0].lo == 0 && is[len(is)-1].hi == Complete {
+		is[0].lo = is[len(is)-1].lo - Complete
+		is = is[:len(is)-1]
+	}
+	arcs := make([]Arc, len(is))
+	for i, iv := range is {
+		arcs[i] = a.arcAlong(dir, iv)
+	}
+	return arcs
+}
+
+// arcAlong returns the arc described by the interval iv along the receiver's sweep in the
+// direction dir.
+func (a Arc) arcAlong(dir Angle, iv interval) Arc {
+	return Arc{Theta: a.Theta + dir*iv.lo, Phi: dir * (iv.hi - iv.lo)}
+}
 
-	return alpha >= Normalize(a.Theta) && alpha <= Normalize(a.Phi)
+// fraction returns the fractional distance of alpha along the sweep of the arc from
+// Theta to Theta+Phi, and whether alpha falls within the arc.
+func (a Arc) fraction(alpha Angle) (float64, bool) {
+	d := alpha - a.Theta
+	phi := a.Phi
+	if phi < 0 {
+		d, phi = -d, -phi
+	}
+	if phi >= Complete {
+		phi = Complete
+	}
+	d = Normalize(d)
+	if d > phi {
+		return math.NaN(), false
+	}
+	if phi == 0 {
+		return 0, true
+	}
+	return float64(d / phi), true
+}
+
+// locate returns the fractional distance of alpha along the sweep of the arc from Theta,
+// and whether alpha falls within the half-open sweep [Theta, Theta+Phi). Since the end of
+// the arc is excluded, an angle on the boundary between two abutting arcs falls within
+// at most one of them. Angles within rounding error of either end are taken to be on
+// that end. An arc of zero sweep holds only Theta.
+func (a Arc) locate(alpha Angle) (float64, bool) {
+	const tol = 1e-9
+	d := alpha - a.Theta
+	phi := a.Phi
+	if phi < 0 {
+		d, phi = -d, -phi
+	}
+	if phi >= Complete {
+		phi = Complete
+	}
+	d = Normalize(d)
+	if d < tol || Complete-d < tol {
+		return 0, true
+	}
+	if d >= phi-tol {
+		return math.NaN(), false
+	}
+	return float64(d / phi), true
+}
+
+// precedes returns whether the feature a sorts before b by name, start and end. It is
+// used to choose deterministically between features found at the same angle.
+func precedes(a, b feat.Feature) bool {
+	switch {
+	case a.Name() != b.Name():
+		return a.Name() < b.Name()
+	case a.Start() != b.Start():
+		return a.Start() < b.Start()
+	default:
+		return a.End() < b.End()
+	}
 }
 
 // Arcs is the base ArcOfer implementation provided by the rings package.
@@ -99,6 +424,71 @@ type Arcs struct {
 // NewGappedArcs returns an Arcs that maps the provided features to the base arc with
 // a fractional gap between each feature.
 func NewGappedArcs(base Arcer, fs []feat.Feature, gap float64) Arcs {
+	return NewSpacedArcs(base, fs, UniformGap(gap))
+}
+
+// Gapper is a type that can specify the fractional gaps between features mapped to an arc.
+type Gapper interface {
+	// Gap returns the fractional size of the ith gap, which lies between the
+	// features left and right. For n features there are n+1 gaps; the first
+	// precedes the first feature and has a nil left, and the last follows the
+	// last feature and has a nil right. When the features fill a complete
+	// circle, the first and last gaps together form the gap at the seam.
+	Gap(i int, left, right feat.Feature) float64
+}
+
+// UniformGap is a Gapper that places the same gap between each pair of features, and
+// half of the gap before the first and after the last feature.
+type UniformGap float64
+
+// Gap returns the gap size for the ith gap.
+func (g UniformGap) Gap(i int, left, right feat.Feature) float64 {
+	if left == nil || right == nil {
+		return float64(g) / 2
+	}
+	return float64(g)
+}
+
+// Gaps is a Gapper that specifies the size of each gap by its index. A Gaps used to
+// map n features must have a length of n+1; NewSpacedArcs, NewLinearArcs and
+// NewSpiralArcs panic if it does not, and NewSpacedBlocks and NewIdeogram return
+// an error.
+type Gaps []float64
+
+// Gap returns the gap size for the ith gap.
+func (g Gaps) Gap(i int, _, _ feat.Feature) float64 { return g[i] }
+
+// GapFunc is a Gapper that determines the size of a gap from the features either side of it.
+type GapFunc func(left, right feat.Feature) float64
+
+// Gap returns the gap size for the gap between left and right.
+func (fn GapFunc) Gap(_ int, left, right feat.Feature) float64 { return fn(left, right) }
+
+// NewSpacedArcs returns an Arcs that maps the provided features to the base arc with
+// fractional gaps around each feature specified by gap. If gap is a Gaps, it must hold
+// one more gap than there are features.
+func NewSpacedArcs(base Arcer, fs []feat.Feature, gap Gapper) Arcs {
+	return spacedArcs(base, fs, gap, Normalize)
+}
+
+// checkGaps returns an error if gap is a Gaps that cannot space n features.
+func checkGaps(gap Gapper, n int) error {
+	if g, ok := gap.(Gaps); ok && len(g) != n+1 {
+		return fmt.Errorf("rings: gaps length mismatch: %d gaps for %d features", len(g), n)
+	}
+	return nil
+}
+
+// spacedArcs returns an Arcs that maps the provided features to the base arc with
+// fractional gaps around each feature specified by gap. The initial angle of each
+// feature's arc is transformed by norm if it is not nil.
+func spacedArcs(base Arcer, fs []feat.Feature, gap Gapper, norm func(Angle) Angle) Arcs {
+	if err := checkGaps(gap, len(fs)); err != nil {
+		panic(err.Error())
+	}
+	if norm == nil {
+		norm = func(theta Angle) Angle { return theta }
+	}
 	arcs := make(map[feat.Feature]Arc, len(fs))
 
 	var total float64
@@ -106,19 +496,33 @@ func NewGappedArcs(base Arcer, fs []feat.Feature, gap float64) Arcs {
 		total += float64(f.Len())
 	}
 
+	gaps := make([]Angle, len(fs)+1)
+	var gapTotal float64
+	for i := range gaps {
+		var left, right feat.Feature
+		if i != 0 {
+			left = fs[i-1]
+		}
+		if i != len(fs) {
+			right = fs[i]
+		}
+		g := gap.Gap(i, left, right)
+		gapTotal += g
+		gaps[i] = Angle(g)
+	}
+
 	arc := base.Arc()
-	scale := arc.Phi * Angle((1-gap*float64(len(fs)))/total)
-	g := Angle(gap) * arc.Phi
+	scale := arc.Phi * Angle((1-gapTotal)/total)
 
-	theta := arc.Theta + g/2
-	for _, f := range fs {
+	theta := arc.Theta + gaps[0]*arc.Phi
+	for i, f := range fs {
 		if fo, ok := f.(featureOrienter); ok && globalOrientation(fo) == feat.Reverse {
 			phi := Angle(f.Len()) * scale
-			arcs[f] = Arc{Theta: Normalize(theta + phi), Phi: -phi}
+			arcs[f] = Arc{Theta: norm(theta + phi), Phi: -phi}
 		} else {
-			arcs[f] = Arc{Theta: Normalize(theta), Phi: Angle(f.Len()) * scale}
+			arcs[f] = Arc{Theta: norm(theta), Phi: Angle(f.Len()) * scale}
 		}
-		theta += Angle(f.Len())*scale + g
+		theta += Angle(f.Len())*scale + gaps[i+1]*arc.Phi
 	}
 
 	return Arcs{Base: arc, Arcs: arcs}
@@ -171,6 +575,77 @@ func (a Arcs) ArcOf(loc, f feat.Feature) (Arc, error) {
 	return arcNaN, errors.New("rings: location not found")
 }
 
+// PositionOf returns the feature held by the Arcs at the angle theta, and the position
+// within the feature corresponding to theta. Reversed feature arcs are taken into
+// account. Each feature arc holds the angles from its start up to but not including its
+// end, so an angle on the boundary between two abutting features maps to only one of
+// them. If more than one feature is found at theta, the first by name, start and end is
+// returned. If no feature is found at theta, a non-nil error is returned.
+func (a Arcs) PositionOf(theta Angle) (f feat.Feature, pos int, err error) {
+	var frac float64
+	for q, arc := range a.Arcs {
+		if q == nil {
+			continue
+		}
+		fr, ok := arc.locate(theta)
+		if !ok || (f != nil && !precedes(q, f)) {
+			continue
+		}
+		f, frac = q, fr
+	}
+	if f == nil {
+		return nil, 0, errors.New("rings: no feature at angle")
+	}
+	return f, f.Start() + int(frac*float64(f.Len())), nil
+}
+
+// ArcsOf appends the arcs of the features in fs, each in the context of its location,
+// to dst and returns the extended slice. The arc of each location is looked up only
+// once, so ArcsOf is considerably faster than repeated calls to ArcOf when many
+// features share a location. If any feature is not found a non-nil error is returned.
+func (a Arcs) ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error) {
+	type locArc struct {
+		arc      Arc
+		min, max int
+		scale    Angle
+	}
+	var (
+		last    feat.Feature
+		lastArc locArc
+		seen    = make(map[feat.Feature]locArc)
+	)
+	for _, f := range fs {
+		loc := f.Location()
+		if loc == nil {
+			arc, err := a.ArcOf(nil, f)
+			if err != nil {
+				return dst, err
+			}
+			dst = append(dst, arc)
+			continue
+		}
+		if loc != last {
+			la, ok := seen[loc]
+			if !ok {
+				fa, ok := a.containingArcOf(loc)
+				if !ok {
+					return dst, errors.New("rings: location not found")
+				}
+				min, max := loc.Start(), loc.End()
+				la = locArc{arc: fa, min: min, max: max, scale: fa.Phi / Angle(max-min)}
+				seen[loc] = la
+			}
+			last, lastArc = loc, la
+		}
+		if f.Start() < lastArc.min || f.Start() > lastArc.max {
+			return dst, errors.New("rings: feature out of range")
+		}
+		start, end := Angle(f.Start()-lastArc.min)*lastArc.scale, Angle(f.End()-lastArc.min)*lastArc.scale
+		dst = append(dst, Arc{start + lastArc.arc.Theta, end - start})
+	}
+	return dst, nil
+}
+
 func contains(loc, f feat.Feature) bool {
 	if loc == f {
 		return true
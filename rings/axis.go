@@ -30,6 +30,17 @@ type Axis struct {
 
 	// Grid is the style of the grid lines.
 	Grid draw.LineStyle
+
+	// MinorGrid is the style of the grid lines at minor tick
+	// marks. If MinorGrid has a nil Color or zero Width, grid
+	// lines at minor tick marks are rendered using Grid.
+	MinorGrid draw.LineStyle
+
+	// Baseline is the style of the grid line and tick mark at the
+	// baseline of renderers that have a baseline, such as Histogram.
+	// If Baseline has a nil Color or zero Width, the baseline is not
+	// marked.
+	Baseline draw.LineStyle
 }
 
 // AxisLabel describes an axis label format and text.
@@ -69,67 +80,121 @@ type TickConfig struct {
 }
 
 // drawAt renders the axis at cen in the specified drawing area, according to the
-// Axis configuration.
-func (r *Axis) drawAt(ca draw.Canvas, cen vg.Point, fs []Scorer, base ArcOfer, inner, outer vg.Length, min, max float64) {
-	locMap := make(map[feat.Feature]struct{})
+// Axis configuration. Tick marks are placed at the radii of their values mapped by
+// scale. If baseline is within the range between min and max, it is marked according
+// to the Axis's Baseline style.
+func (r *Axis) drawAt(ca draw.Canvas, cen vg.Point, fs []Scorer, base ArcOfer, inner, outer vg.Length, min, max float64, scale valueScale, baseline float64) {
+	r.drawGrid(ca, cen, locationsOf(fs), base, inner, outer, min, max, scale, baseline)
+	r.drawSpoke(ca, cen, projectionOf(base), r.Angle, inner, outer, min, max, scale, baseline)
+}
 
-	var (
-		pa vg.Path
+// locationsOf returns the distinct locations of the provided features in order of
+// first appearance.
+func locationsOf(fs []Scorer) []feat.Feature {
+	var locs []feat.Feature
+	seen := make(map[feat.Feature]struct{})
+	for _, f := range fs {
+		loc := f.Location()
+		if _, ok := seen[loc]; ok {
+			continue
+		}
+		seen[loc] = struct{}{}
+		locs = append(locs, loc)
+	}
+	return locs
+}
 
-		marks []plot.Tick
+// marksBaseline returns whether the baseline is marked for the range between min and max.
+func (r *Axis) marksBaseline(min, max, baseline float64) bool {
+	return r.Baseline.Color != nil && r.Baseline.Width != 0 && min <= baseline && baseline <= max
+}
+
+// drawGrid renders the grid lines and baseline of the axis across the arcs of the
+// provided locations.
+func (r *Axis) drawGrid(ca draw.Canvas, cen vg.Point, locs []feat.Feature, base ArcOfer, inner, outer vg.Length, min, max float64, scale valueScale, baseline float64) {
+	grid := r.Grid.Color != nil && r.Grid.Width != 0
+	minorGrid := grid && r.MinorGrid.Color != nil && r.MinorGrid.Width != 0
+	markBase := r.marksBaseline(min, max, baseline)
+	if !grid && !markBase {
+		return
+	}
 
-		scale = (outer - inner) / vg.Length(max-min)
+	var (
+		pa    vg.Path
+		marks []plot.Tick
 	)
-	for _, f := range fs {
-		locMap[f.Location()] = struct{}{}
+	if grid {
+		marks = r.Tick.Marker.Ticks(min, max)
 	}
-	if r.Grid.Color != nil && r.Grid.Width != 0 {
-		for loc := range locMap {
-			arc, err := base.ArcOf(loc, nil)
-			if err != nil {
-				panic(fmt.Sprint("rings: no arc for feature location:", err))
-			}
+	proj := projectionOf(base)
+	for _, loc := range locs {
+		arc, err := base.ArcOf(loc, nil)
+		if err != nil {
+			panic(fmt.Sprint("rings: no arc for feature location:", err))
+		}
+
+		// line renders a grid line at the radius of v.
+		line := func(v float64) {
+			pa = pa[:0]
+
+			radius := scale.radius(v, inner, outer)
+
+			pa.Move(cen.Add(proj.Point(arc.Theta, radius)))
+			proj.Arc(&pa, cen, radius, arc.Theta, arc.Phi)
+
+			ca.Stroke(pa)
+		}
 
+		if grid {
 			ca.SetLineStyle(r.Grid)
-			marks = r.Tick.Marker.Ticks(min, max)
 			for _, mark := range marks {
-				if mark.Value < min || mark.Value > max {
+				if mark.Value < min || mark.Value > max || (markBase && mark.Value == baseline) || (minorGrid && mark.IsMinor()) {
 					continue
 				}
-				pa = pa[:0]
-
-				radius := vg.Length(mark.Value-min)*scale + inner
-
-				pa.Move(cen.Add(Rectangular(arc.Theta, radius)))
-				pa.Arc(cen, radius, float64(arc.Theta), float64(arc.Phi))
-
-				ca.Stroke(pa)
+				line(mark.Value)
 			}
+			if minorGrid {
+				ca.SetLineStyle(r.MinorGrid)
+				for _, mark := range marks {
+					if mark.Value < min || mark.Value > max || (markBase && mark.Value == baseline) || !mark.IsMinor() {
+						continue
+					}
+					line(mark.Value)
+				}
+			}
+		}
+
+		if markBase {
+			ca.SetLineStyle(r.Baseline)
+			line(baseline)
 		}
 	}
+}
+
+// drawSpoke renders the axis line, tick marks and label of the axis at angle.
+func (r *Axis) drawSpoke(ca draw.Canvas, cen vg.Point, proj Projection, angle Angle, inner, outer vg.Length, min, max float64, scale valueScale, baseline float64) {
+	var pa vg.Path
 
 	if r.LineStyle.Color != nil && r.LineStyle.Width != 0 {
 		pa = pa[:0]
 
-		pa.Move(cen.Add(Rectangular(r.Angle, inner)))
-		pa.Line(cen.Add(Rectangular(r.Angle, outer)))
+		pa.Move(cen.Add(proj.Point(angle, inner)))
+		pa.Line(cen.Add(proj.Point(angle, outer)))
 
 		ca.SetLineStyle(r.LineStyle)
 		ca.Stroke(pa)
 	}
 
 	if r.Tick.LineStyle.Color != nil && r.Tick.LineStyle.Width != 0 && r.Tick.Length != 0 {
+		markBase := r.marksBaseline(min, max, baseline)
 		ca.SetLineStyle(r.Tick.LineStyle)
-		if marks == nil {
-			marks = r.Tick.Marker.Ticks(min, max)
-		}
-		for _, mark := range marks {
+		for _, mark := range r.Tick.Marker.Ticks(min, max) {
 			if mark.Value < min || mark.Value > max {
 				continue
 			}
 			pa = pa[:0]
 
-			radius := vg.Length(mark.Value-min)*scale + inner
+			radius := scale.radius(mark.Value, inner, outer)
 
 			var length vg.Length
 			if mark.IsMinor() {
@@ -137,26 +202,32 @@ func (r *Axis) drawAt(ca draw.Canvas, cen vg.Point, fs []Scorer, base ArcOfer, i
 			} else {
 				length = r.Tick.Length
 			}
-			off := Rectangular(r.Angle+Complete/4, length)
-			e := Rectangular(r.Angle, radius)
+			off := Rectangular(proj.Normal(angle)+Complete/4, length)
+			e := proj.Point(angle, radius)
 			pa.Move(cen.Add(e))
 			pa.Line(cen.Add(e.Add(off)))
 
-			ca.Stroke(pa)
+			if markBase && mark.Value == baseline {
+				ca.SetLineStyle(r.Baseline)
+				ca.Stroke(pa)
+				ca.SetLineStyle(r.Tick.LineStyle)
+			} else {
+				ca.Stroke(pa)
+			}
 
 			if mark.IsMinor() || r.Tick.Label.Color == nil {
 				continue
 			}
 
-			pt := cen.Add(Rectangular(r.Angle, radius).Add(vg.Point{off.X * 2, off.Y * 2}))
+			pt := cen.Add(e.Add(vg.Point{off.X * 2, off.Y * 2}))
 			var (
 				rot            Angle
 				xalign, yalign float64
 			)
 			if r.Tick.Placement == nil {
-				rot, xalign, yalign = DefaultPlacement(r.Angle)
+				rot, xalign, yalign = DefaultPlacement(proj.Normal(angle))
 			} else {
-				rot, xalign, yalign = r.Tick.Placement(r.Angle)
+				rot, xalign, yalign = r.Tick.Placement(proj.Normal(angle))
 			}
 			r.Tick.Label.XAlign = draw.XAlignment(xalign)
 			r.Tick.Label.YAlign = draw.YAlignment(yalign)
@@ -166,15 +237,15 @@ func (r *Axis) drawAt(ca draw.Canvas, cen vg.Point, fs []Scorer, base ArcOfer, i
 	}
 
 	if r.Label.Text != "" && r.Label.Color != nil {
-		pt := cen.Add(Rectangular(r.Angle, (inner+outer)/2))
+		pt := cen.Add(proj.Point(angle, (inner+outer)/2))
 		var (
 			rot            Angle
 			xalign, yalign float64
 		)
 		if r.Label.Placement == nil {
-			rot, xalign, yalign = DefaultPlacement(r.Angle)
+			rot, xalign, yalign = DefaultPlacement(proj.Normal(angle))
 		} else {
-			rot, xalign, yalign = r.Label.Placement(r.Angle)
+			rot, xalign, yalign = r.Label.Placement(proj.Normal(angle))
 		}
 		r.Label.TextStyle.XAlign = draw.XAlignment(xalign)
 		r.Label.TextStyle.YAlign = draw.YAlignment(yalign)
@@ -182,3 +253,129 @@ func (r *Axis) drawAt(ca draw.Canvas, cen vg.Point, fs []Scorer, base ArcOfer, i
 		ca.FillText(r.Label.TextStyle, pt, r.Label.Text)
 	}
 }
+
+// scoreMapper is a ScoreRenderer that maps scores to radii between the inner and
+// outer radii of a Scores.
+type scoreMapper interface {
+	// scoreMapping returns the transform, orientation and score range used
+	// to map scores to radii when the renderer is configured with the score
+	// range min to max.
+	scoreMapping(min, max float64) (tr Transform, inward bool, lo, hi float64)
+}
+
+// RadialAxis implements rendering of radial axes and grid lines describing the radial
+// scale of a Scores ring separately from the rendering of the Scores. The radial scale
+// is taken from the Scores' Renderer where it maps scores to radii.
+type RadialAxis struct {
+	// Scores is the Scores ring described by the RadialAxis. Axes
+	// are rendered between the Inner and Outer radii of Scores and
+	// grid lines are rendered across the locations of its Set.
+	Scores *Scores
+
+	// Axis describes the axis configuration. The Angle field
+	// of Axis is ignored.
+	Axis Axis
+
+	// Angles specifies the angular locations of the axes.
+	Angles []Angle
+
+	// AtLocations specifies that an axis is rendered at the start
+	// of each location of the features of Scores, in addition to
+	// the axes specified by Angles.
+	AtLocations bool
+
+	// Min and Max override the score range of the axis. If Min
+	// and Max are both zero, the range used by the Scores' Renderer
+	// is used if it maps scores to radii, otherwise the range that
+	// the Scores passes to its Renderer is used.
+	Min, Max float64
+
+	// Transform and Inward override the mapping of scores to
+	// radii. If Transform is nil, the Transform of the Scores'
+	// Renderer is used if it maps scores to radii. Scores are
+	// mapped inward if Inward is true or the Renderer maps
+	// scores inward.
+	Transform Transform
+	Inward    bool
+
+	// Baseline is the score value marked according to the
+	// Axis's Baseline style.
+	Baseline float64
+
+	// X and Y specify rendering location when Plot is called.
+	X, Y float64
+}
+
+// NewRadialAxis returns a RadialAxis describing s with the provided axis configuration
+// and axis angles.
+func NewRadialAxis(s *Scores, ax Axis, angles ...Angle) *RadialAxis {
+	return &RadialAxis{Scores: s, Axis: ax, Angles: angles}
+}
+
+// DrawAt renders the grid lines and axes of a RadialAxis at cen in the specified drawing
+// area, according to the RadialAxis configuration.
+func (r *RadialAxis) DrawAt(ca draw.Canvas, cen vg.Point) {
+	s := r.Scores
+	if len(s.Set) == 0 {
+		return
+	}
+
+	// Resolve the scale as the Scores' DrawAt and Renderer
+	// do so that the axis is independent of drawing order.
+	_, min, max, err := s.renderable()
+	if err != nil {
+		panic(fmt.Sprint("rings: cannot bin scores:", err))
+	}
+	tr, inward := r.Transform, r.Inward
+	if m, ok := s.Renderer.(scoreMapper); ok {
+		var (
+			mtr     Transform
+			minward bool
+		)
+		mtr, minward, min, max = m.scoreMapping(min, max)
+		if tr == nil {
+			tr = mtr
+		}
+		inward = inward || minward
+	}
+	if r.Min != 0 || r.Max != 0 {
+		min, max = r.Min, r.Max
+	}
+	scale := newValueScale(tr, min, max)
+	scale.inward = inward
+
+	locs := locationsOf(s.Set)
+	r.Axis.drawGrid(ca, cen, locs, s.Base, s.Inner, s.Outer, min, max, scale, r.Baseline)
+
+	angles := r.Angles
+	if r.AtLocations {
+		angles = make([]Angle, len(r.Angles), len(r.Angles)+len(locs))
+		copy(angles, r.Angles)
+		for _, loc := range locs {
+			arc, err := s.Base.ArcOf(loc, nil)
+			if err != nil {
+				panic(fmt.Sprint("rings: no arc for feature location:", err))
+			}
+			angles = append(angles, arc.Theta)
+		}
+	}
+	proj := projectionOf(s.Base)
+	for _, angle := range angles {
+		r.Axis.drawSpoke(ca, cen, proj, angle, s.Inner, s.Outer, min, max, scale, r.Baseline)
+	}
+}
+
+// Plot calls DrawAt using the RadialAxis' X and Y values as the drawing coordinates.
+func (r *RadialAxis) Plot(ca draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&ca)
+	r.DrawAt(ca, vg.Point{trX(r.X), trY(r.Y)})
+}
+
+// GlyphBoxes returns a liberal glyphbox for the axis rendering.
+func (r *RadialAxis) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Scores.Base).Bounds(r.Scores.Outer + 2*r.Axis.Tick.Length),
+	}}
+}
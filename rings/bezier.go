@@ -76,31 +76,45 @@ type Bezier struct {
 // ControlPoints returns a set of Bézier curve control points defining the path between the points defined
 // by the parameters and the Bezier's Radius, Crest and Purity fields.
 func (b *Bezier) ControlPoints(a [2]Angle, rad [2]vg.Length) []vg.Point {
+	return b.controlPoints([2]Projection{polar{}, polar{}}, a, rad)
+}
+
+// controlPoints returns the Bézier curve control points for ControlPoints with the ends
+// of the curve placed according to the projections in proj. The projection of the first
+// end is used for the crest of the curve.
+func (b *Bezier) controlPoints(proj [2]Projection, a [2]Angle, rad [2]vg.Length) []vg.Point {
+	_, isPolar := proj[0].(polar)
+
 	var p [2]vg.Point
 	for i := range a {
-		p[i] = Rectangular(a[i], rad[i])
+		p[i] = proj[i].Point(a[i], rad[i])
 	}
 
 	var radius = b.Radius
 	if b.Purity != nil {
-		bisectRadius := vg.Length(math.Hypot(float64(p[0].X+p[1].X)/2, float64(p[0].Y+p[1].Y)/2))
+		var bisectRadius vg.Length
+		if isPolar {
+			bisectRadius = vg.Length(math.Hypot(float64(p[0].X+p[1].X)/2, float64(p[0].Y+p[1].Y)/2))
+		} else {
+			bisectRadius = (rad[0] + rad[1]) / 2
+		}
 		radius.Length += vg.Length(b.Purity.Perturb(rand.Float64())-1) * (radius.Length - bisectRadius)
 	}
 
 	var bisect Angle
-	if math.Abs(float64(a[1]-a[0])) > math.Pi {
+	if isPolar && math.Abs(float64(a[1]-a[0])) > math.Pi {
 		bisect = (a[0]+a[1]+Angle(2*math.Pi))/2 - Angle(2*math.Pi)
 	} else {
 		bisect = (a[1] + a[0]) / 2
 	}
-	mid := Rectangular(bisect, radius.Perturb(rand.Float64()))
+	mid := proj[0].Point(bisect, radius.Perturb(rand.Float64()))
 
 	if b.Crest != nil {
 		points := []vg.Point{0: p[0], 2: mid, 4: p[1]}
 		c := b.Crest.Perturb(rand.Float64())
 
 		for i, r := range rad {
-			points[2*i+1] = Rectangular(a[i], r-(r-radius.Length)*vg.Length(c))
+			points[2*i+1] = proj[i].Point(a[i], r-(r-radius.Length)*vg.Length(c))
 		}
 		return points
 	}
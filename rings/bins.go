@@ -0,0 +1,214 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// Reducer is a function that aggregates the score values held by a bin into a
+// single value. The values passed to a Reducer are never empty and do not include
+// NaN values. A Reducer may alter the order of the values.
+type Reducer func(values []float64) float64
+
+// MeanReducer returns the mean of values.
+func MeanReducer(values []float64) float64 { return SumReducer(values) / float64(len(values)) }
+
+// MaxReducer returns the maximum of values.
+func MaxReducer(values []float64) float64 {
+	max := values[0]
+	for _, v := range values[1:] {
+		max = math.Max(max, v)
+	}
+	return max
+}
+
+// MinReducer returns the minimum of values.
+func MinReducer(values []float64) float64 {
+	min := values[0]
+	for _, v := range values[1:] {
+		min = math.Min(min, v)
+	}
+	return min
+}
+
+// SumReducer returns the sum of values.
+func SumReducer(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// MedianReducer returns the median of values. If the number of values is even, the
+// mean of the two central values is returned. MedianReducer sorts values.
+func MedianReducer(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// Bins describes the aggregation of Scorers into windows of a fixed size on each
+// feature location. Each Scorer contributes its scores to every bin it overlaps and
+// the scores in each bin are reduced to a single value for each score series. Bins
+// holding no Scorers are not rendered and Scorers without a location are rendered
+// individually.
+type Bins struct {
+	// Width is the width of each bin in the coordinates of the
+	// features' locations. If Width is zero, bin widths are
+	// determined by Resolution. Width must not be negative.
+	Width int
+
+	// Resolution is the target angular width of each bin. It is
+	// used to determine the bin width on each location when Width
+	// is zero. If both Width and Resolution are zero, Scorers are
+	// not binned.
+	Resolution Angle
+
+	// Reduce is the function used to aggregate the scores in
+	// each bin. If Reduce is nil, MeanReducer is used.
+	Reduce Reducer
+}
+
+// width returns the bin width for the location loc rendered on base.
+func (b *Bins) width(base ArcOfer, loc feat.Feature) (int, error) {
+	if b.Width != 0 || b.Resolution == 0 {
+		return b.Width, nil
+	}
+	arc, err := base.ArcOf(loc, nil)
+	if err != nil {
+		return 0, err
+	}
+	w := int(math.Ceil(float64(b.Resolution/arc.Phi) * float64(loc.Len())))
+	if w < 0 {
+		w = -w
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w, nil
+}
+
+// bin returns the bins of the provided Scorers rendered on base. Bins are returned
+// grouped by location in order of first appearance of the location in fs and in
+// order of position within each location, followed by the Scorers without a location,
+// which are not binned.
+func (b *Bins) bin(base ArcOfer, fs []feat.Feature) ([]feat.Feature, error) {
+	if b.Width < 0 {
+		return nil, errors.New("rings: negative bin width")
+	}
+	if b.Width == 0 && b.Resolution == 0 {
+		return fs, nil
+	}
+	reduce := b.Reduce
+	if reduce == nil {
+		reduce = MeanReducer
+	}
+
+	type binKey struct {
+		loc feat.Feature
+		k   int
+	}
+	var (
+		locs   []feat.Feature
+		widths = make(map[feat.Feature]int)
+		bins   = make(map[binKey][][]float64)
+		keys   = make(map[feat.Feature][]int)
+	)
+	var unbinned []feat.Feature
+	for _, f := range fs {
+		loc := f.Location()
+		if loc == nil {
+			unbinned = append(unbinned, f)
+			continue
+		}
+		w, ok := widths[loc]
+		if !ok {
+			var err error
+			w, err = b.width(base, loc)
+			if err != nil {
+				return nil, err
+			}
+			widths[loc] = w
+			locs = append(locs, loc)
+		}
+
+		first := (f.Start() - loc.Start()) / w
+		last := first
+		if f.End() > f.Start() {
+			last = (f.End() - 1 - loc.Start()) / w
+		}
+		scores := f.(Scorer).Scores()
+		for k := first; k <= last; k++ {
+			key := binKey{loc: loc, k: k}
+			series, ok := bins[key]
+			if !ok {
+				keys[loc] = append(keys[loc], k)
+			}
+			for len(series) < len(scores) {
+				series = append(series, nil)
+			}
+			for j, v := range scores {
+				if math.IsNaN(v) {
+					continue
+				}
+				series[j] = append(series[j], v)
+			}
+			bins[key] = series
+		}
+	}
+
+	var binned []feat.Feature
+	for _, loc := range locs {
+		w := widths[loc]
+		ks := keys[loc]
+		sort.Ints(ks)
+		for _, k := range ks {
+			series := bins[binKey{loc: loc, k: k}]
+			s := &binScorer{
+				start:    loc.Start() + k*w,
+				end:      loc.Start() + (k+1)*w,
+				location: loc,
+				scores:   make([]float64, len(series)),
+			}
+			if s.end > loc.End() {
+				s.end = loc.End()
+			}
+			for j, values := range series {
+				if len(values) == 0 {
+					s.scores[j] = math.NaN()
+					continue
+				}
+				s.scores[j] = reduce(values)
+			}
+			binned = append(binned, s)
+		}
+	}
+	return append(binned, unbinned...), nil
+}
+
+// binScorer is a Scorer holding the aggregated scores of a bin.
+type binScorer struct {
+	start, end int
+	location   feat.Feature
+	scores     []float64
+}
+
+func (s *binScorer) Start() int             { return s.start }
+func (s *binScorer) End() int               { return s.end }
+func (s *binScorer) Len() int               { return s.end - s.start }
+func (s *binScorer) Name() string           { return fmt.Sprintf("%s:%d-%d", s.location.Name(), s.start, s.end) }
+func (s *binScorer) Description() string    { return "bin" }
+func (s *binScorer) Location() feat.Feature { return s.location }
+func (s *binScorer) Scores() []float64      { return s.scores }
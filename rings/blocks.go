@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"image/color"
+	"math"
+	"sort"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -36,12 +38,183 @@ type Blocks struct {
 	// Inner and Outer define the inner and outer radii of the blocks.
 	Inner, Outer vg.Length
 
+	// Tiles specifies the stacking of overlapping blocks into layers. If Tiles
+	// is nil, all blocks are rendered between Inner and Outer.
+	Tiles *Tiles
+
+	// Shape specifies the shape of the blocks. Arrow and Chevron shapes point
+	// in the direction of the orientation of features that are feat.Orienters.
+	// Features that are not oriented, or are too short to hold the arrowhead,
+	// are rendered as annular sectors.
+	Shape BlockShape
+
+	// Head is the angular length of the arrowheads of Arrow and Chevron blocks.
+	Head Angle
+
+	// Rules specifies styling rules applied to each block after
+	// any style defined by the feature itself. If Rules is nil, no rules
+	// are applied.
+	Rules *Rules
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
 
+// BlockShape specifies the shape of a rendered block.
+type BlockShape int
+
+const (
+	// Sector blocks are annular sectors.
+	Sector BlockShape = iota
+	// Arrow blocks are annular sectors with an arrowhead at the leading end.
+	Arrow
+	// Chevron blocks are arrow blocks with a notch at the trailing end.
+	Chevron
+)
+
+// TileDirection specifies the direction in which the layers of a Tiles are stacked.
+type TileDirection int
+
+const (
+	// TileOutward stacks layers outward from the inner radius of a Blocks.
+	TileOutward TileDirection = iota
+	// TileInward stacks layers inward from the outer radius of a Blocks.
+	TileInward
+)
+
+// TileOverflow specifies the handling of blocks that do not fit within the maximum
+// number of layers of a Tiles.
+type TileOverflow int
+
+const (
+	// OverflowHide specifies that blocks that do not fit are not rendered.
+	OverflowHide TileOverflow = iota
+	// OverflowCollapse specifies that blocks that do not fit are rendered on
+	// the last layer, overlapping the blocks already placed there.
+	OverflowCollapse
+)
+
+// Tiles describes the packing of overlapping blocks into stacked layers. Blocks are placed
+// in the first layer holding no block that they overlap, in order of their start angle.
+// When rendered in polar coordinates, blocks that cross angle zero overlap the blocks at
+// the start of the circle. Layers are stacked between the inner and outer radii of the
+// Blocks, so the number of layers is limited by the space between them.
+type Tiles struct {
+	// Thickness is the radial thickness of each layer.
+	Thickness vg.Length
+
+	// Padding is the radial space between adjacent layers.
+	Padding vg.Length
+
+	// Margin is the minimum angular space between blocks in the same layer.
+	Margin Angle
+
+	// MaxLayers is the maximum number of layers. If MaxLayers is zero, the
+	// number of layers is limited only by the number of layers that fit
+	// between the inner and outer radii of the Blocks. At least one layer
+	// is always used.
+	MaxLayers int
+
+	// Overflow specifies the handling of blocks that do not fit within MaxLayers.
+	Overflow TileOverflow
+
+	// Direction specifies the direction in which layers are stacked.
+	Direction TileDirection
+}
+
+// layers returns the layer index of each of the provided arcs rendered with the projection
+// proj between the inner and outer radii. Arcs that are not rendered are given the layer
+// index -1.
+func (t *Tiles) layers(proj Projection, arcs []Arc, inner, outer vg.Length) []int {
+	_, circular := proj.(polar)
+	spans := make(tileSpans, len(arcs))
+	for i, arc := range arcs {
+		lo, hi := arc.Theta, arc.Theta+arc.Phi
+		if hi < lo {
+			lo, hi = hi, lo
+		}
+		if circular {
+			// Place the start of each span in [0, Complete) so
+			// that spans are ordered around the circle.
+			d := hi - lo
+			lo = Normalize(lo)
+			hi = lo + d
+		}
+		spans[i] = tileSpan{i: i, lo: lo, hi: hi}
+	}
+	sort.Stable(spans)
+
+	max := t.MaxLayers
+	if step := t.Thickness + t.Padding; step > 0 {
+		n := int((outer - inner + t.Padding) / step)
+		if n < 1 {
+			n = 1
+		}
+		if max == 0 || n < max {
+			max = n
+		}
+	}
+
+	layer := make([]int, len(arcs))
+	var starts, ends []Angle
+	for _, s := range spans {
+		k := 0
+		for ; k < len(ends); k++ {
+			if ends[k]+t.Margin > s.lo {
+				continue
+			}
+			// In polar coordinates, the part of a span beyond
+			// Complete wraps to overlap the start of the layer.
+			if circular && s.hi-Complete+t.Margin > starts[k] {
+				continue
+			}
+			break
+		}
+		if k == len(ends) {
+			if max == 0 || k < max {
+				starts = append(starts, s.lo)
+				ends = append(ends, s.hi)
+			} else if t.Overflow == OverflowCollapse {
+				k--
+			} else {
+				layer[s.i] = -1
+				continue
+			}
+		}
+		if s.hi > ends[k] {
+			ends[k] = s.hi
+		}
+		layer[s.i] = k
+	}
+	return layer
+}
+
+// tileSpan is the angular extent of the ith block of a Blocks.
+type tileSpan struct {
+	i      int
+	lo, hi Angle
+}
+
+// tileSpans sorts a set of tileSpans by ascending start angle.
+type tileSpans []tileSpan
+
+func (s tileSpans) Len() int           { return len(s) }
+func (s tileSpans) Less(i, j int) bool { return s[i].lo < s[j].lo }
+func (s tileSpans) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// radii returns the inner and outer radii of the kth layer of tiles based on the provided
+// inner and outer radii.
+func (t *Tiles) radii(k int, inner, outer vg.Length) (vg.Length, vg.Length) {
+	off := vg.Length(k) * (t.Thickness + t.Padding)
+	if t.Direction == TileInward {
+		return outer - off - t.Thickness, outer - off
+	}
+	return inner + off, inner + off + t.Thickness
+}
+
 // NewBlocks returns a Blocks based on the parameters, first checking that the provided features
-// are able to be rendered. An error is returned if the features are not renderable.
+// are able to be rendered. An error is returned if the features are not renderable. Features
+// outside the display windows of base are not rendered.
 func NewBlocks(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Blocks, error) {
 	if inner > outer {
 		return nil, errors.New("rings: inner radius greater than outer radius")
@@ -55,7 +228,7 @@ func NewBlocks(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Blocks
 				return nil, errors.New("rings: feature out of range")
 			}
 		}
-		if _, err := base.ArcOf(f, nil); err != nil {
+		if _, err := base.ArcOf(f, nil); err != nil && err != ErrOutsideWindows {
 			return nil, err
 		}
 	}
@@ -71,21 +244,30 @@ func NewBlocks(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Blocks
 // of the provided Arcer. If the provided Arcer is an ArcOfer it is tested for validity and a new ArcOfer is
 // created only if needed.
 func NewGappedBlocks(fs []feat.Feature, base Arcer, inner, outer vg.Length, gap float64) (*Blocks, error) {
+	return NewSpacedBlocks(fs, base, inner, outer, UniformGap(gap))
+}
+
+// NewSpacedBlocks is a convenience wrapper of NewBlocks that guarantees to provide a valid ArcOfer based
+// of the provided Arcer, using the provided Gapper to space the features if a new ArcOfer is needed. If the
+// provided Arcer is an ArcOfer it is tested for validity and a new ArcOfer is created only if needed.
+func NewSpacedBlocks(fs []feat.Feature, base Arcer, inner, outer vg.Length, gap Gapper) (*Blocks, error) {
 	if inner > outer {
 		return nil, errors.New("rings: inner radius greater than outer radius")
 	}
-	var b ArcOfer
-	switch base := base.(type) {
-	case ArcOfer:
-		b = base
+	b, ok := base.(ArcOfer)
+	if ok {
 		for _, f := range fs {
-			if _, err := base.ArcOf(f, nil); err != nil {
-				b = NewGappedArcs(base, fs, gap)
+			if _, err := b.ArcOf(f, nil); err != nil && err != ErrOutsideWindows {
+				ok = false
 				break
 			}
 		}
-	default:
-		b = NewGappedArcs(base, fs, gap)
+	}
+	if !ok {
+		if err := checkGaps(gap, len(fs)); err != nil {
+			return nil, err
+		}
+		b = NewSpacedArcs(base, fs, gap)
 	}
 	return NewBlocks(fs, b, inner, outer)
 }
@@ -97,41 +279,61 @@ func (r *Blocks) DrawAt(ca draw.Canvas, cen vg.Point) {
 		return
 	}
 
+	arcs, set, err := visibleArcsOf(r.Base, make([]Arc, 0, len(r.Set)), r.Set)
+	if err != nil {
+		panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+	}
+
+	proj := projectionOf(r.Base)
+
+	var layers []int
+	if r.Tiles != nil {
+		layers = r.Tiles.layers(proj, arcs, r.Inner, r.Outer)
+	}
+
 	var pa vg.Path
-	for _, f := range r.Set {
+	for i, f := range set {
+		sty := Style{Fill: r.Color, Line: r.LineStyle}
+		if c, ok := f.(FillColorer); ok {
+			sty.Fill = c.FillColor()
+		}
+		if ls, ok := f.(LineStyler); ok {
+			sty.Line = ls.LineStyle()
+		}
+		sty, visible := r.Rules.apply(featureItem(f), sty)
+		if !visible {
+			continue
+		}
+
 		pa = pa[:0]
 
-		arc, err := r.Base.ArcOf(f.Location(), f)
-		if err != nil {
-			panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+		arc := arcs[i]
+		inner, outer := r.Inner, r.Outer
+		if layers != nil {
+			if layers[i] < 0 {
+				continue
+			}
+			inner, outer = r.Tiles.radii(layers[i], r.Inner, r.Outer)
 		}
 
-		pa.Move(cen.Add(Rectangular(arc.Theta, r.Inner)))
-		pa.Arc(cen, r.Inner, float64(arc.Theta), float64(arc.Phi))
-		if arc.Phi == Clockwise*Complete || arc.Phi == CounterClockwise*Complete {
-			if c, ok := f.(feat.Conformationer); ok && c.Conformation() == feat.Circular {
-				pa.Move(cen.Add(Rectangular(arc.Theta+arc.Phi, r.Outer)))
+		if !r.arrowPath(&pa, proj, cen, f, arc, inner, outer) {
+			pa.Move(cen.Add(proj.Point(arc.Theta, inner)))
+			proj.Arc(&pa, cen, inner, arc.Theta, arc.Phi)
+			if arc.Phi == Clockwise*Complete || arc.Phi == CounterClockwise*Complete {
+				if c, ok := f.(feat.Conformationer); ok && c.Conformation() == feat.Circular {
+					pa.Move(cen.Add(proj.Point(arc.Theta+arc.Phi, outer)))
+				}
 			}
+			proj.Arc(&pa, cen, outer, arc.Theta+arc.Phi, -arc.Phi)
 		}
-		pa.Arc(cen, r.Outer, float64(arc.Theta+arc.Phi), float64(-arc.Phi))
 		pa.Close()
 
-		if c, ok := f.(FillColorer); ok {
-			ca.SetColor(c.FillColor())
-			ca.Fill(pa)
-		} else if r.Color != nil {
-			ca.SetColor(r.Color)
+		if sty.Fill != nil {
+			ca.SetColor(sty.Fill)
 			ca.Fill(pa)
 		}
-
-		var sty draw.LineStyle
-		if ls, ok := f.(LineStyler); ok {
-			sty = ls.LineStyle()
-		} else {
-			sty = r.LineStyle
-		}
-		if sty.Color != nil && sty.Width != 0 {
-			ca.SetLineStyle(sty)
+		if sty.Line.Color != nil && sty.Line.Width != 0 {
+			ca.SetLineStyle(sty.Line)
 			ca.Stroke(pa)
 		}
 	}
@@ -147,6 +349,78 @@ func (r *Blocks) Arc() Arc { return r.Base.Arc() }
 // the Blocks, an error is returned.
 func (r *Blocks) ArcOf(loc, f feat.Feature) (Arc, error) { return r.Base.ArcOf(loc, f) }
 
+// Projection returns the projection of the Blocks' Base.
+func (r *Blocks) Projection() Projection { return projectionOf(r.Base) }
+
+// ArcsOf appends the Arc locations of the features in fs to dst. If any feature is not
+// found in the Blocks, an error is returned.
+func (r *Blocks) ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error) { return arcsOf(r.Base, dst, fs) }
+
+// arrowPath adds the path of an Arrow or Chevron shaped block for the feature f with the
+// specified arc and radii to pa. It returns false without altering pa if the block should
+// be rendered as a sector.
+func (r *Blocks) arrowPath(pa *vg.Path, proj Projection, cen vg.Point, f feat.Feature, arc Arc, inner, outer vg.Length) bool {
+	if r.Shape == Sector || r.Head <= 0 {
+		return false
+	}
+	o, ok := f.(feat.Orienter)
+	if !ok || o.Orientation() == feat.NotOriented {
+		return false
+	}
+	if o.Orientation() == feat.Reverse {
+		arc = Arc{Theta: arc.Theta + arc.Phi, Phi: -arc.Phi}
+	}
+	head := r.Head
+	if arc.Phi < 0 {
+		head = -head
+	}
+	body := arc.Phi - head
+	min := Angle(0)
+	if r.Shape == Chevron {
+		min = head
+	}
+	if math.Abs(float64(body)) < math.Abs(float64(min)) || body*head < 0 {
+		return false
+	}
+
+	mid := (inner + outer) / 2
+	pa.Move(cen.Add(proj.Point(arc.Theta, inner)))
+	proj.Arc(pa, cen, inner, arc.Theta, body)
+	pa.Line(cen.Add(proj.Point(arc.Theta+arc.Phi, mid)))
+	pa.Line(cen.Add(proj.Point(arc.Theta+body, outer)))
+	proj.Arc(pa, cen, outer, arc.Theta+body, -body)
+	if r.Shape == Chevron {
+		pa.Line(cen.Add(proj.Point(arc.Theta+head, mid)))
+	}
+	return true
+}
+
+// PositionOf returns the block feature at the angle theta and the position within the
+// feature corresponding to theta. Each block holds the angles from its start up to but
+// not including its end. If no block is found at theta, an error is returned.
+func (r *Blocks) PositionOf(theta Angle) (f feat.Feature, pos int, err error) {
+	for _, f := range r.Set {
+		arc, err := r.Base.ArcOf(f.Location(), f)
+		if err != nil {
+			continue
+		}
+		frac, ok := arc.locate(theta)
+		if !ok {
+			continue
+		}
+		if p, ok := r.Base.(PositionOfer); ok {
+			// Use the base mapping where it is in the coordinates
+			// of the block since it may not be uniform.
+			bf, pos, err := p.PositionOf(theta)
+			if err == nil && (bf == f || bf == f.Location()) {
+				return f, pos, nil
+			}
+		}
+		return f, f.Start() + int(frac*float64(f.Len())), nil
+	}
+	return nil, 0, errors.New("rings: no feature at angle")
+}
+
 type featureOrienter interface {
 	feat.Feature
 	feat.Orienter
@@ -169,11 +443,8 @@ func (r *Blocks) Plot(ca draw.Canvas, plt *plot.Plot) {
 // GlyphBoxes returns a liberal glyphbox for the blocks rendering.
 func (r *Blocks) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-r.Outer, -r.Outer},
-			Max: vg.Point{r.Outer, r.Outer},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(r.Outer),
 	}}
 }
@@ -0,0 +1,334 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Diverging is a palette.ColorMap that maps values either side of a center value onto
+// the two halves of an underlying color map, so that the center value is rendered with
+// the middle color of the underlying map regardless of the symmetry of the range. The
+// underlying map is only evaluated within its own range, so diverging maps that do not
+// support off-center convergence points may be centred on any value.
+type Diverging struct {
+	cmap     palette.ColorMap
+	center   float64
+	min, max float64
+}
+
+// errCenterRange is returned by Diverging's At method when the center is not
+// between the minimum and maximum values.
+var errCenterRange = errors.New("rings: diverging center out of range")
+
+// NewDiverging returns a Diverging based on cmap, mapping values from min to center onto
+// the lower half of cmap's range and values from center to max onto the upper half.
+// NewDiverging will panic if center is not between min and max.
+func NewDiverging(cmap palette.ColorMap, min, center, max float64) *Diverging {
+	if !(min <= center && center <= max) {
+		panic(fmt.Sprintf("rings: diverging center %v not in range [%v, %v]", center, min, max))
+	}
+	return &Diverging{cmap: cmap, center: center, min: min, max: max}
+}
+
+// At returns the color associated with v. If v is not between Min and Max, or Center
+// is not between Min and Max, an error is returned.
+func (d *Diverging) At(v float64) (color.Color, error) {
+	switch {
+	case !(d.min <= d.center && d.center <= d.max):
+		return nil, errCenterRange
+	case math.IsNaN(v):
+		return nil, palette.ErrNaN
+	case v < d.min:
+		return nil, palette.ErrUnderflow
+	case v > d.max:
+		return nil, palette.ErrOverflow
+	}
+	lo, hi := d.cmap.Min(), d.cmap.Max()
+	mid := (lo + hi) / 2
+	var x float64
+	switch {
+	case v == d.center:
+		x = mid
+	case v < d.center:
+		x = lo + (v-d.min)/(d.center-d.min)*(mid-lo)
+	default:
+		x = mid + (v-d.center)/(d.max-d.center)*(hi-mid)
+	}
+	return d.cmap.At(math.Min(math.Max(x, lo), hi))
+}
+
+// Center returns the value rendered with the middle color of the underlying color map.
+func (d *Diverging) Center() float64 { return d.center }
+
+// SetCenter sets the value rendered with the middle color of the underlying color map.
+func (d *Diverging) SetCenter(v float64) { d.center = v }
+
+// Max returns the maximum value of the Diverging.
+func (d *Diverging) Max() float64 { return d.max }
+
+// SetMax sets the maximum value of the Diverging.
+func (d *Diverging) SetMax(v float64) { d.max = v }
+
+// Min returns the minimum value of the Diverging.
+func (d *Diverging) Min() float64 { return d.min }
+
+// SetMin sets the minimum value of the Diverging.
+func (d *Diverging) SetMin(v float64) { d.min = v }
+
+// Alpha returns the opacity value of the underlying color map.
+func (d *Diverging) Alpha() float64 { return d.cmap.Alpha() }
+
+// SetAlpha sets the opacity value of the underlying color map.
+func (d *Diverging) SetAlpha(alpha float64) { d.cmap.SetAlpha(alpha) }
+
+// Palette returns a palette.Palette with the specified number of colors evenly
+// spaced between Min and Max.
+func (d *Diverging) Palette(colors int) palette.Palette {
+	p := make(colorList, colors)
+	for i := range p {
+		v := d.min
+		if colors > 1 {
+			v += float64(i) / float64(colors-1) * (d.max - d.min)
+		}
+		c, err := d.At(v)
+		if err != nil {
+			panic(err)
+		}
+		p[i] = c
+	}
+	return p
+}
+
+// colorList is a palette.Palette holding a list of colors.
+type colorList []color.Color
+
+func (p colorList) Colors() []color.Color { return p }
+
+// defaultColorBarSteps is the number of color blocks used to render a ColorMap
+// when a ColorBar's Steps field is zero.
+const defaultColorBarSteps = 64
+
+// ColorBar implements rendering of a color bar legend describing the score to color
+// mapping of a Heat.
+type ColorBar struct {
+	// Heat is the Heat described by the ColorBar. Unless the Heat has a ColorMap, its
+	// Min and Max must be set, either explicitly or by rendering the Scores that use it,
+	// before the ColorBar is rendered.
+	Heat *Heat
+
+	// Length and Width are the length of the bar along its scale and its thickness.
+	Length, Width vg.Length
+
+	// Vertical specifies that the bar is rendered vertically. Otherwise
+	// the bar is rendered horizontally.
+	Vertical bool
+
+	// Steps is the number of color blocks used to render a ColorMap.
+	// If Steps is zero, 64 blocks are used.
+	Steps int
+
+	// LineStyle is the style of the outline of the bar and the
+	// Underflow and Overflow swatches.
+	LineStyle draw.LineStyle
+
+	// Tick describes the scale's tick configuration. Ticks are rendered
+	// below a horizontal bar and to the right of a vertical bar. Ticks
+	// are marked in score values. If the Heat has a ColorMap, ticks are
+	// marked in the range of scores that transform to the ColorMap's
+	// range, otherwise they are marked in the Heat's score range. If
+	// the Heat has both a ColorMap and a Transform, the Transform must
+	// be one of the Transforms provided by this package or DrawAt will
+	// panic, since only these can be inverted.
+	Tick TickConfig
+
+	// X and Y specify rendering location of the start of the bar when Plot is called.
+	X, Y float64
+}
+
+// NewColorBar returns a ColorBar describing h with the specified length and width.
+func NewColorBar(h *Heat, length, width vg.Length) *ColorBar {
+	return &ColorBar{Heat: h, Length: length, Width: width}
+}
+
+// DrawAt renders the ColorBar with the start of the bar at cen in the specified drawing
+// area, according to the ColorBar configuration. Underflow and Overflow swatches are
+// rendered before the start and after the end of the bar if the Heat's Underflow and
+// Overflow colors are not nil.
+func (r *ColorBar) DrawAt(ca draw.Canvas, cen vg.Point) {
+	h := r.Heat
+
+	// pt returns the point at distance a along the bar and w across it.
+	pt := func(a, w vg.Length) vg.Point {
+		if r.Vertical {
+			return cen.Add(vg.Point{X: w, Y: a})
+		}
+		return cen.Add(vg.Point{X: a, Y: w})
+	}
+	var pa vg.Path
+	// block fills the block from a0 to a1 along the bar and from w0 to w1 across
+	// it with c, leaving the block's path in pa.
+	block := func(a0, a1, w0, w1 vg.Length, c color.Color) {
+		pa = pa[:0]
+		pa.Move(pt(a0, w0))
+		pa.Line(pt(a1, w0))
+		pa.Line(pt(a1, w1))
+		pa.Line(pt(a0, w1))
+		pa.Close()
+		if c != nil {
+			ca.SetColor(c)
+			ca.Fill(pa)
+		}
+	}
+
+	var (
+		min, max float64
+		scale    valueScale
+	)
+	if cm := h.ColorMap; cm != nil {
+		min, max = cm.Min(), cm.Max()
+		n := r.Steps
+		if n == 0 {
+			n = defaultColorBarSteps
+		}
+		for k := 0; k < n; k++ {
+			c, err := cm.At(min + (float64(k)+0.5)/float64(n)*(max-min))
+			if err != nil {
+				continue
+			}
+			block(r.Length*vg.Length(k)/vg.Length(n), r.Length*vg.Length(k+1)/vg.Length(n), 0, r.Width, c)
+		}
+
+		// The ColorMap's range holds transformed scores,
+		// so ticks are marked in the inverted range.
+		if h.Transform != nil {
+			inv, ok := h.Transform.(inverter)
+			if !ok {
+				panic(fmt.Sprintf("rings: cannot invert transform %T for color bar ticks", h.Transform))
+			}
+			min, max = inv.inverse(min), inv.inverse(max)
+			if min > max {
+				min, max = max, min
+			}
+		}
+		scale = newValueScale(h.Transform, min, max)
+	} else {
+		min, max = h.Min, h.Max
+		scale = newValueScale(h.Transform, min, max)
+		n := len(h.Palette)
+		for k, c := range h.Palette {
+			// Palette colors are chosen by rounding, so the end
+			// colors cover half the width of the others.
+			a0, a1 := vg.Length(0), r.Length
+			if n > 1 {
+				a0 = r.Length * vg.Length(math.Max(0, (float64(k)-0.5)/float64(n-1)))
+				a1 = r.Length * vg.Length(math.Min(1, (float64(k)+0.5)/float64(n-1)))
+			}
+			block(a0, a1, 0, r.Width, c)
+		}
+	}
+
+	sty := r.LineStyle
+	outline := sty.Color != nil && sty.Width != 0
+	if outline {
+		block(0, r.Length, 0, r.Width, nil)
+		ca.SetLineStyle(sty)
+		ca.Stroke(pa)
+	}
+	for _, swatch := range []struct {
+		a0, a1 vg.Length
+		c      color.Color
+	}{
+		{a0: -r.Width * 3 / 2, a1: -r.Width / 2, c: h.Underflow},
+		{a0: r.Length + r.Width/2, a1: r.Length + r.Width*3/2, c: h.Overflow},
+	} {
+		if swatch.c == nil {
+			continue
+		}
+		block(swatch.a0, swatch.a1, 0, r.Width, swatch.c)
+		if outline {
+			ca.SetLineStyle(sty)
+			ca.Stroke(pa)
+		}
+	}
+
+	if r.Tick.Marker == nil || r.Tick.LineStyle.Color == nil || r.Tick.LineStyle.Width == 0 || r.Tick.Length == 0 {
+		return
+	}
+	// Ticks extend away from the bar, below it when
+	// horizontal and to its right when vertical.
+	dir := -Complete / 4
+	w, off := vg.Length(0), -r.Tick.Length
+	if r.Vertical {
+		dir = 0
+		w, off = r.Width, r.Tick.Length
+	}
+	ca.SetLineStyle(r.Tick.LineStyle)
+	for _, mark := range r.Tick.Marker.Ticks(min, max) {
+		if mark.Value < min || mark.Value > max {
+			continue
+		}
+		a := scale.radius(mark.Value, 0, r.Length)
+
+		length := off
+		if mark.IsMinor() {
+			length /= 2
+		}
+		pa = pa[:0]
+		pa.Move(pt(a, w))
+		pa.Line(pt(a, w+length))
+		ca.Stroke(pa)
+
+		if mark.IsMinor() || r.Tick.Label.Color == nil {
+			continue
+		}
+		var (
+			rot            Angle
+			xalign, yalign float64
+		)
+		if r.Tick.Placement == nil {
+			rot, xalign, yalign = Horizontal(dir)
+		} else {
+			rot, xalign, yalign = r.Tick.Placement(dir)
+		}
+		lab := r.Tick.Label
+		lab.XAlign = draw.XAlignment(xalign)
+		lab.YAlign = draw.YAlignment(yalign)
+		lab.Rotation = float64(rot)
+		ca.FillText(lab, pt(a, w+2*length), mark.Label)
+	}
+}
+
+// Plot calls DrawAt using the ColorBar's X and Y values as the drawing coordinates.
+func (r *ColorBar) Plot(ca draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&ca)
+	r.DrawAt(ca, vg.Point{trX(r.X), trY(r.Y)})
+}
+
+// GlyphBoxes returns a liberal glyphbox for the color bar rendering.
+func (r *ColorBar) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	a0, a1 := -r.Width*3/2, r.Length+r.Width*3/2
+	w0, w1 := -2*r.Tick.Length, r.Width
+	if r.Vertical {
+		w0, w1 = 0, r.Width+2*r.Tick.Length
+	}
+	rect := vg.Rectangle{Min: vg.Point{X: a0, Y: w0}, Max: vg.Point{X: a1, Y: w1}}
+	if r.Vertical {
+		rect = vg.Rectangle{Min: vg.Point{X: w0, Y: a0}, Max: vg.Point{X: w1, Y: a1}}
+	}
+	return []plot.GlyphBox{{
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: rect,
+	}}
+}
@@ -0,0 +1,299 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+
+	"github.com/biogo/biogo/feat"
+	"github.com/biogo/biogo/feat/gene"
+)
+
+// Exoner is a feature composed of exons, such as a gene.Transcript. The exons must be
+// ordered by start position and have positions relative to the start of the feature.
+type Exoner interface {
+	feat.Feature
+	Exons() gene.Exons
+}
+
+// CDSer is an Exoner that has a coding region, such as a gene.CodingTranscript. The
+// coding region has positions relative to the start of the feature.
+type CDSer interface {
+	Exoner
+	CDS() feat.Feature
+}
+
+// Genes implements rendering of transcript structures, with exons rendered as radial
+// blocks joined by intron lines.
+type Genes struct {
+	// Set holds a collection of Exoners to render.
+	Set []feat.Feature
+
+	// Base defines the targets of the rendered transcripts.
+	Base ArcOfer
+
+	// Color determines the fill color of each exon block. If Color is not nil each block is
+	// rendered filled with the specified color, otherwise no fill is performed. This behaviour
+	// is over-ridden if the feature describing the transcript is a FillColorer.
+	Color color.Color
+
+	// LineStyle determines the line style of each exon block. LineStyle behaviour
+	// is over-ridden if the feature describing the transcript is a LineStyler.
+	LineStyle draw.LineStyle
+
+	// IntronStyle determines the line style of intron lines and chevrons. IntronStyle
+	// behaviour is over-ridden if the feature describing the transcript is a LineStyler.
+	IntronStyle draw.LineStyle
+
+	// Inner and Outer define the inner and outer radii of the transcripts. Introns
+	// are rendered midway between Inner and Outer.
+	Inner, Outer vg.Length
+
+	// ExonHeight and UTRHeight specify the radial heights of exon blocks as fractions
+	// of the distance between Inner and Outer. Coding exon regions of CDSers and the
+	// exons of other Exoners are rendered with ExonHeight, and untranslated exon
+	// regions of CDSers are rendered with UTRHeight.
+	ExonHeight, UTRHeight float64
+
+	// ChevronSpacing is the angular spacing of strand chevrons rendered along the
+	// introns of features that are feat.Orienters. If ChevronSpacing is zero, no
+	// chevrons are rendered.
+	ChevronSpacing Angle
+
+	// X and Y specify rendering location when Plot is called.
+	X, Y float64
+}
+
+// NewGenes returns a Genes based on the parameters, first checking that the provided features
+// are able to be rendered. An error is returned if the features are not renderable. The exons
+// of the returned Genes are rendered with the full height between inner and outer, and UTRs
+// with half that height.
+func NewGenes(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Genes, error) {
+	if inner > outer {
+		return nil, errors.New("rings: inner radius greater than outer radius")
+	}
+	for _, f := range fs {
+		if _, ok := f.(Exoner); !ok {
+			return nil, fmt.Errorf("rings: cannot render a type %T as a gene", f)
+		}
+		if f.End() < f.Start() {
+			return nil, errors.New("rings: inverted feature")
+		}
+		if _, err := base.ArcOf(f, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &Genes{
+		Set:        fs,
+		Base:       base,
+		Inner:      inner,
+		Outer:      outer,
+		ExonHeight: 1,
+		UTRHeight:  0.5,
+	}, nil
+}
+
+// DrawAt renders the transcripts of a Genes at cen in the specified drawing area,
+// according to the Genes configuration.
+func (r *Genes) DrawAt(ca draw.Canvas, cen vg.Point) {
+	if len(r.Set) == 0 {
+		return
+	}
+
+	proj := projectionOf(r.Base)
+	mid := (r.Inner + r.Outer) / 2
+	exonHalf := (r.Outer - r.Inner) * vg.Length(r.ExonHeight) / 2
+	utrHalf := (r.Outer - r.Inner) * vg.Length(r.UTRHeight) / 2
+
+	var pa vg.Path
+	for _, f := range r.Set {
+		if _, err := r.Base.ArcOf(f.Location(), f); err != nil {
+			if err == ErrOutsideWindows {
+				continue
+			}
+			panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+		}
+		exons := f.(Exoner).Exons()
+
+		sty := r.IntronStyle
+		if ls, ok := f.(LineStyler); ok {
+			sty = ls.LineStyle()
+		}
+		if sty.Color != nil && sty.Width != 0 && len(exons) > 1 {
+			ca.SetLineStyle(sty)
+			for j := 1; j < len(exons); j++ {
+				intron, ok := r.subArc(f, exons[j-1].End(), exons[j].Start())
+				if !ok {
+					continue
+				}
+				pa = pa[:0]
+				pa.Move(cen.Add(proj.Point(intron.Theta, mid)))
+				proj.Arc(&pa, cen, mid, intron.Theta, intron.Phi)
+				ca.Stroke(pa)
+
+				r.drawChevrons(ca, proj, cen, f, intron, mid, utrHalf)
+			}
+		}
+
+		var fill color.Color
+		if c, ok := f.(FillColorer); ok {
+			fill = c.FillColor()
+		} else {
+			fill = r.Color
+		}
+		sty = r.LineStyle
+		if ls, ok := f.(LineStyler); ok {
+			sty = ls.LineStyle()
+		}
+
+		cdsStart, cdsEnd := 0, f.Len()
+		var coding bool
+		if c, ok := f.(CDSer); ok {
+			cds := c.CDS()
+			cdsStart, cdsEnd = cds.Start(), cds.End()
+			coding = true
+		}
+		for _, e := range exons {
+			for _, part := range [...]struct {
+				start, end int
+				half       vg.Length
+			}{
+				{e.Start(), imin(e.End(), cdsStart), utrHalf},
+				{imax(e.Start(), cdsStart), imin(e.End(), cdsEnd), exonHalf},
+				{imax(e.Start(), cdsEnd), e.End(), utrHalf},
+			} {
+				if part.start >= part.end {
+					continue
+				}
+				if !coding {
+					part.half = exonHalf
+				}
+				block, ok := r.subArc(f, part.start, part.end)
+				if !ok {
+					continue
+				}
+				inner, outer := mid-part.half, mid+part.half
+
+				pa = pa[:0]
+				pa.Move(cen.Add(proj.Point(block.Theta, inner)))
+				proj.Arc(&pa, cen, inner, block.Theta, block.Phi)
+				proj.Arc(&pa, cen, outer, block.Theta+block.Phi, -block.Phi)
+				pa.Close()
+
+				if fill != nil {
+					ca.SetColor(fill)
+					ca.Fill(pa)
+				}
+				if sty.Color != nil && sty.Width != 0 {
+					ca.SetLineStyle(sty)
+					ca.Stroke(pa)
+				}
+			}
+		}
+	}
+}
+
+// drawChevrons renders strand chevrons along the intron arc of the feature f at the radius
+// mid, with the chevron arms extending half radially from mid. The line style must be set
+// before calling drawChevrons.
+func (r *Genes) drawChevrons(ca draw.Canvas, proj Projection, cen vg.Point, f feat.Feature, intron Arc, mid, half vg.Length) {
+	if r.ChevronSpacing <= 0 {
+		return
+	}
+	o, ok := f.(feat.Orienter)
+	if !ok || o.Orientation() == feat.NotOriented {
+		return
+	}
+	n := int(math.Abs(float64(intron.Phi / r.ChevronSpacing)))
+	if n == 0 {
+		return
+	}
+	step := intron.Phi / Angle(n)
+	w := step / 4
+	if o.Orientation() == feat.Reverse {
+		w = -w
+	}
+
+	var pa vg.Path
+	for k := 0; k < n; k++ {
+		a := intron.Theta + (Angle(k)+0.5)*step
+		pa = pa[:0]
+		pa.Move(cen.Add(proj.Point(a-w, mid+half)))
+		pa.Line(cen.Add(proj.Point(a+w, mid)))
+		pa.Line(cen.Add(proj.Point(a-w, mid-half)))
+		ca.Stroke(pa)
+	}
+}
+
+// subArc returns the arc of the region from start to end, relative to the start of the
+// transcript f, as mapped by the Genes' Base, and whether the region is mapped. Regions
+// are mapped in the coordinates of the location of f, so non-uniform mappings such as
+// those of a SegmentedArcs are respected and regions lying outside the windows of a
+// windowed Base are not mapped.
+func (r *Genes) subArc(f feat.Feature, start, end int) (Arc, bool) {
+	loc := f.Location()
+	if loc == nil {
+		loc = f
+	}
+	arc, err := r.Base.ArcOf(loc, region{loc: loc, start: f.Start() + start, end: f.Start() + end})
+	if err != nil {
+		return arcNaN, false
+	}
+	return arc, true
+}
+
+// region is a region of a transcript in the coordinates of the transcript's location.
+type region struct {
+	loc        feat.Feature
+	start, end int
+}
+
+func (r region) Start() int             { return r.start }
+func (r region) End() int               { return r.end }
+func (r region) Len() int               { return r.end - r.start }
+func (r region) Name() string           { return fmt.Sprintf("%s:%d-%d", r.loc.Name(), r.start, r.end) }
+func (r region) Description() string    { return "transcript region" }
+func (r region) Location() feat.Feature { return r.loc }
+
+// imin returns the lesser of a and b.
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// imax returns the greater of a and b.
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Projection returns the projection of the Genes' Base.
+func (r *Genes) Projection() Projection { return projectionOf(r.Base) }
+
+// Plot calls DrawAt using the Genes' X and Y values as the drawing coordinates.
+func (r *Genes) Plot(ca draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&ca)
+	r.DrawAt(ca, vg.Point{trX(r.X), trY(r.Y)})
+}
+
+// GlyphBoxes returns a liberal glyphbox for the genes rendering.
+func (r *Genes) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(r.Outer),
+	}}
+}
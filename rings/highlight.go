@@ -26,6 +26,10 @@ type Highlight struct {
 	// Inner and Outer define the inner and outer radii of the blocks.
 	Inner, Outer vg.Length
 
+	// Projector specifies the projection used to render the highlight.
+	// If Projector is nil, the highlight is rendered in polar coordinates.
+	Projector Projector
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
@@ -48,14 +52,16 @@ func (r *Highlight) DrawAt(ca draw.Canvas, cen vg.Point) {
 		return
 	}
 
+	proj := r.Projection()
+
 	var pa vg.Path
 
-	pa.Move(cen.Add(Rectangular(r.Base.Theta, r.Inner)))
-	pa.Arc(cen, r.Inner, float64(r.Base.Theta), float64(r.Base.Phi))
+	pa.Move(cen.Add(proj.Point(r.Base.Theta, r.Inner)))
+	proj.Arc(&pa, cen, r.Inner, r.Base.Theta, r.Base.Phi)
 	if r.Base.Phi == Clockwise*Complete || r.Base.Phi == CounterClockwise*Complete {
-		pa.Move(cen.Add(Rectangular(r.Base.Theta+r.Base.Phi, r.Outer)))
+		pa.Move(cen.Add(proj.Point(r.Base.Theta+r.Base.Phi, r.Outer)))
 	}
-	pa.Arc(cen, r.Outer, float64(r.Base.Theta+r.Base.Phi), float64(-r.Base.Phi))
+	proj.Arc(&pa, cen, r.Outer, r.Base.Theta+r.Base.Phi, -r.Base.Phi)
 	pa.Close()
 
 	if r.Color != nil {
@@ -74,6 +80,9 @@ func (r *Highlight) XY() (x, y float64) { return r.X, r.Y }
 // Arc returns the arc of the Highlight.
 func (r *Highlight) Arc() Arc { return r.Base }
 
+// Projection returns the projection of the Highlight's Projector.
+func (r *Highlight) Projection() Projection { return projectionOf(r.Projector) }
+
 // Plot calls DrawAt using the Highlight's X and Y values as the drawing coordinates.
 func (r *Highlight) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -83,11 +92,8 @@ func (r *Highlight) Plot(ca draw.Canvas, plt *plot.Plot) {
 // GlyphBoxes returns a liberal glyphbox for the highlight rendering.
 func (r *Highlight) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-r.Outer, -r.Outer},
-			Max: vg.Point{r.Outer, r.Outer},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: r.Projection().Bounds(r.Outer),
 	}}
 }
@@ -0,0 +1,391 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+
+	"github.com/biogo/biogo/feat"
+	"github.com/biogo/biogo/feat/genome"
+)
+
+// GiemsaPalette maps Giemsa stain values, such as "gneg", "gpos50" or "acen", to band colors.
+type GiemsaPalette map[string]color.Color
+
+// clone returns a copy of p.
+func (p GiemsaPalette) clone() GiemsaPalette {
+	c := make(GiemsaPalette, len(p))
+	for stain, col := range p {
+		c[stain] = col
+	}
+	return c
+}
+
+var (
+	// Giemsa is a grey scale Giemsa palette with red centromeres, lavender variable
+	// heterochromatin and black stalks.
+	Giemsa = GiemsaPalette{
+		"acen":    color.RGBA{R: 0xff, A: 0xff},
+		"gvar":    color.RGBA{R: 0xbc, G: 0xbd, B: 0xdc, A: 0xff},
+		"stalk":   color.Gray{0x0},
+		"gneg":    color.Gray{0xff},
+		"gpos25":  color.Gray{3 * math.MaxUint8 / 4},
+		"gpos33":  color.Gray{2 * math.MaxUint8 / 3},
+		"gpos50":  color.Gray{math.MaxUint8 / 2},
+		"gpos66":  color.Gray{math.MaxUint8 / 3},
+		"gpos75":  color.Gray{math.MaxUint8 / 4},
+		"gpos100": color.Gray{0x0},
+	}
+
+	// CircosGiemsa is the Giemsa palette used by Circos karyotype ideograms.
+	CircosGiemsa = GiemsaPalette{
+		"acen":    color.RGBA{R: 217, G: 47, B: 39, A: 0xff},
+		"gvar":    color.RGBA{R: 220, G: 220, B: 220, A: 0xff},
+		"stalk":   color.RGBA{R: 100, G: 127, B: 164, A: 0xff},
+		"gneg":    color.RGBA{R: 255, G: 255, B: 255, A: 0xff},
+		"gpos25":  color.RGBA{R: 200, G: 200, B: 200, A: 0xff},
+		"gpos33":  color.RGBA{R: 210, G: 210, B: 210, A: 0xff},
+		"gpos50":  color.RGBA{R: 200, G: 200, B: 200, A: 0xff},
+		"gpos66":  color.RGBA{R: 160, G: 160, B: 160, A: 0xff},
+		"gpos75":  color.RGBA{R: 130, G: 130, B: 130, A: 0xff},
+		"gpos100": color.RGBA{A: 0xff},
+	}
+)
+
+// CentromereShape specifies the shape of the centromeric bands of an Ideogram.
+type CentromereShape int
+
+const (
+	// PointedCentromere centromeric bands taper linearly to a point at the centromere.
+	PointedCentromere CentromereShape = iota
+	// RoundedCentromere centromeric bands taper with a rounded profile to the centromere.
+	RoundedCentromere
+)
+
+// roundedSteps is the number of segments used to approximate a rounded centromeric band.
+const roundedSteps = 16
+
+// Ideogram implements rendering of cytogenetic karyotype ideograms, with chromosomes rendered
+// as radial blocks holding their Giemsa stained bands.
+//
+// Bands with the "acen" stain are rendered tapering to the centromere, with the taper of bands
+// on the p arm toward their end and the taper of bands on the q arm toward their start. Bands with
+// the "stalk" stain are rendered narrowed. The centromeres of chromosomes without "acen" bands are
+// inferred from the start of the first q arm band following the p arm, and are rendered as radial
+// marks. Chromosomes without "acen" bands whose first band is a q arm band starting at the start of
+// the chromosome, such as acrocentric mouse chromosomes, have their first band rendered tapering to
+// the centromere at the p-terminal end, according to the Centromere shape.
+type Ideogram struct {
+	// Chromosomes holds the chromosomes to render.
+	Chromosomes []*genome.Chromosome
+
+	// Bands holds the cytogenetic bands to render. Bands are expected to be
+	// ordered from the p arm to the q arm of each chromosome.
+	Bands []*genome.Band
+
+	// Base defines the targets of the rendered ideogram.
+	Base ArcOfer
+
+	// Palette determines the fill color of each band according to its Giemsa
+	// stain. Bands with stains not found in Palette are not filled.
+	Palette GiemsaPalette
+
+	// Centromere specifies the shape of centromeric bands.
+	Centromere CentromereShape
+
+	// CentromereStyle determines the line style of inferred centromere marks.
+	CentromereStyle draw.LineStyle
+
+	// StalkHeight specifies the radial height of stalk bands as a fraction of
+	// the distance between Inner and Outer.
+	StalkHeight float64
+
+	// HatchSpacing is the greatest angular spacing of the radial hatching drawn
+	// over bands with the "gvar" stain. Hatch lines are evenly spaced across each
+	// band. If HatchSpacing is zero, no hatching is drawn.
+	HatchSpacing Angle
+
+	// HatchStyle determines the line style of gvar band hatching.
+	HatchStyle draw.LineStyle
+
+	// LineStyle determines the line style of chromosome outlines.
+	LineStyle draw.LineStyle
+
+	// Inner and Outer define the inner and outer radii of the chromosomes.
+	Inner, Outer vg.Length
+
+	// TextStyle determines the text style of chromosome name labels. If the
+	// text style has a nil Color or a zero font size, no names are rendered.
+	TextStyle draw.TextStyle
+
+	// LabelRadius is the radius at which chromosome names are rendered.
+	LabelRadius vg.Length
+
+	// Placement determines the text rotation and alignment of chromosome names.
+	// If Placement is nil, DefaultPlacement is used.
+	Placement TextPlacement
+
+	// X and Y specify rendering location when Plot is called.
+	X, Y float64
+}
+
+// NewIdeogram returns an Ideogram based on the parameters, first checking that the provided
+// chromosomes and bands are able to be rendered. If the provided Arcer is an ArcOfer holding the
+// chromosomes it is used as the Base of the Ideogram, otherwise an Arcs is created with the fractional
+// gaps between chromosomes specified by gap. An error is returned if the features are not renderable.
+// Bands outside the display windows of the base are not rendered. The returned Ideogram uses a copy
+// of the Giemsa palette and renders inferred centromeres in red.
+func NewIdeogram(chrs []*genome.Chromosome, bands []*genome.Band, base Arcer, inner, outer vg.Length, gap Gapper) (*Ideogram, error) {
+	if inner > outer {
+		return nil, errors.New("rings: inner radius greater than outer radius")
+	}
+	fs := make([]feat.Feature, len(chrs))
+	for i, c := range chrs {
+		fs[i] = c
+	}
+	b, ok := base.(ArcOfer)
+	if ok {
+		for _, f := range fs {
+			if _, err := b.ArcOf(f, nil); err != nil {
+				ok = false
+				break
+			}
+		}
+	}
+	if !ok {
+		if err := checkGaps(gap, len(fs)); err != nil {
+			return nil, err
+		}
+		b = NewSpacedArcs(base, fs, gap)
+	}
+	for _, band := range bands {
+		if band.Chr == nil {
+			return nil, errors.New("rings: band has no chromosome")
+		}
+		if band.End() < band.Start() {
+			return nil, errors.New("rings: inverted feature")
+		}
+		if band.Start() < band.Chr.Start() || band.End() > band.Chr.End() {
+			return nil, errors.New("rings: feature out of range")
+		}
+		if _, err := b.ArcOf(band.Chr, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &Ideogram{
+		Chromosomes:     chrs,
+		Bands:           bands,
+		Base:            b,
+		Palette:         Giemsa.clone(),
+		CentromereStyle: draw.LineStyle{Color: color.RGBA{R: 0xff, A: 0xff}, Width: 1},
+		StalkHeight:     0.5,
+		Inner:           inner,
+		Outer:           outer,
+		LabelRadius:     outer,
+	}, nil
+}
+
+// DrawAt renders the chromosomes and bands of an Ideogram at cen in the specified drawing area,
+// according to the Ideogram configuration.
+func (r *Ideogram) DrawAt(ca draw.Canvas, cen vg.Point) {
+	proj := projectionOf(r.Base)
+	mid := (r.Inner + r.Outer) / 2
+	half := (r.Outer - r.Inner) / 2
+
+	acen := make(map[feat.Feature]bool)
+	for _, b := range r.Bands {
+		if b.Giemsa == "acen" {
+			acen[b.Chr] = true
+		}
+	}
+
+	var (
+		pa   vg.Path
+		cens []Angle
+	)
+	prev := make(map[feat.Feature]*genome.Band)
+	for _, b := range r.Bands {
+		arc, err := r.Base.ArcOf(b.Chr, b)
+		if err == ErrOutsideWindows {
+			continue
+		}
+		if err != nil {
+			panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+		}
+
+		var terminal bool
+		if !acen[b.Chr] && isArm(b, 'q') {
+			p, ok := prev[b.Chr]
+			switch {
+			case !ok && b.Start() == b.Chr.Start():
+				terminal = true
+			case !ok || isArm(p, 'p'):
+				cens = append(cens, arc.Theta)
+			}
+		}
+		prev[b.Chr] = b
+
+		c := r.Palette[b.Giemsa]
+		if c == nil {
+			continue
+		}
+		pa = pa[:0]
+		switch {
+		case terminal:
+			r.taperPath(&pa, proj, cen, Arc{Theta: arc.Theta + arc.Phi, Phi: -arc.Phi}, mid, half)
+		case b.Giemsa == "acen":
+			if isArm(b, 'q') {
+				arc = Arc{Theta: arc.Theta + arc.Phi, Phi: -arc.Phi}
+			}
+			r.taperPath(&pa, proj, cen, arc, mid, half)
+		case b.Giemsa == "stalk":
+			h := half * vg.Length(r.StalkHeight)
+			sectorPath(&pa, proj, cen, arc, mid-h, mid+h)
+		default:
+			sectorPath(&pa, proj, cen, arc, r.Inner, r.Outer)
+		}
+		ca.SetColor(c)
+		ca.Fill(pa)
+
+		if b.Giemsa == "gvar" && !terminal && r.HatchSpacing > 0 && r.HatchStyle.Color != nil && r.HatchStyle.Width != 0 {
+			ca.SetLineStyle(r.HatchStyle)
+			n := int(math.Abs(float64(arc.Phi / r.HatchSpacing)))
+			step := arc.Phi / Angle(n+1)
+			for k := 1; k <= n; k++ {
+				a := arc.Theta + Angle(k)*step
+				pa = pa[:0]
+				pa.Move(cen.Add(proj.Point(a, r.Inner)))
+				pa.Line(cen.Add(proj.Point(a, r.Outer)))
+				ca.Stroke(pa)
+			}
+		}
+	}
+
+	if sty := r.CentromereStyle; sty.Color != nil && sty.Width != 0 && len(cens) != 0 {
+		ca.SetLineStyle(sty)
+		for _, a := range cens {
+			pa = pa[:0]
+			pa.Move(cen.Add(proj.Point(a, r.Inner)))
+			pa.Line(cen.Add(proj.Point(a, r.Outer)))
+			ca.Stroke(pa)
+		}
+	}
+
+	if sty := r.LineStyle; sty.Color != nil && sty.Width != 0 {
+		ca.SetLineStyle(sty)
+		for _, c := range r.Chromosomes {
+			arc, err := r.Base.ArcOf(c, nil)
+			if err != nil {
+				panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+			}
+			pa = pa[:0]
+			sectorPath(&pa, proj, cen, arc, r.Inner, r.Outer)
+			ca.Stroke(pa)
+		}
+	}
+
+	if sty := r.TextStyle; sty.Color != nil && sty.Font.Size != 0 {
+		for _, c := range r.Chromosomes {
+			arc, err := r.Base.ArcOf(c, nil)
+			if err != nil {
+				panic(fmt.Sprintf("rings: no arc for feature location: %v", err))
+			}
+			angle := arc.Theta + arc.Phi/2
+			var (
+				rot            Angle
+				xalign, yalign float64
+			)
+			if r.Placement == nil {
+				rot, xalign, yalign = DefaultPlacement(proj.Normal(angle))
+			} else {
+				rot, xalign, yalign = r.Placement(proj.Normal(angle))
+			}
+			sty.XAlign = draw.XAlignment(xalign)
+			sty.YAlign = draw.YAlignment(yalign)
+			sty.Rotation = float64(rot)
+			ca.FillText(sty, cen.Add(proj.Point(angle, r.LabelRadius)), c.Name())
+		}
+	}
+}
+
+// isArm returns whether the band b is on the chromosome arm named arm.
+func isArm(b *genome.Band, arm byte) bool { return len(b.Band) != 0 && b.Band[0] == arm }
+
+// taperPath adds the path of a band on arc that tapers from its full height at the start of
+// the arc to the centromere at the end of the arc to pa, according to the Centromere shape.
+func (r *Ideogram) taperPath(pa *vg.Path, proj Projection, cen vg.Point, arc Arc, mid, half vg.Length) {
+	n := 1
+	if r.Centromere == RoundedCentromere {
+		n = roundedSteps
+	}
+	height := func(i int) vg.Length {
+		t := float64(i) / float64(n)
+		if r.Centromere == RoundedCentromere {
+			return half * vg.Length(math.Sqrt(1-t*t))
+		}
+		return half * vg.Length(1-t)
+	}
+	pa.Move(cen.Add(proj.Point(arc.Theta, mid-half)))
+	for i := 0; i <= n; i++ {
+		pa.Line(cen.Add(proj.Point(arc.Theta+arc.Phi*Angle(i)/Angle(n), mid+height(i))))
+	}
+	for i := n - 1; i > 0; i-- {
+		pa.Line(cen.Add(proj.Point(arc.Theta+arc.Phi*Angle(i)/Angle(n), mid-height(i))))
+	}
+	pa.Close()
+}
+
+// sectorPath adds the path of a block on arc between the inner and outer radii to pa.
+func sectorPath(pa *vg.Path, proj Projection, cen vg.Point, arc Arc, inner, outer vg.Length) {
+	pa.Move(cen.Add(proj.Point(arc.Theta, inner)))
+	proj.Arc(pa, cen, inner, arc.Theta, arc.Phi)
+	proj.Arc(pa, cen, outer, arc.Theta+arc.Phi, -arc.Phi)
+	pa.Close()
+}
+
+// XY returns the x and y coordinates of the Ideogram.
+func (r *Ideogram) XY() (x, y float64) { return r.X, r.Y }
+
+// Arc returns the base arc of the Ideogram.
+func (r *Ideogram) Arc() Arc { return r.Base.Arc() }
+
+// ArcOf returns the Arc location of the parameter. If the location is not found in
+// the Ideogram, an error is returned.
+func (r *Ideogram) ArcOf(loc, f feat.Feature) (Arc, error) { return r.Base.ArcOf(loc, f) }
+
+// ArcsOf appends the Arc locations of the features in fs to dst. If any feature is not
+// found in the Ideogram, an error is returned.
+func (r *Ideogram) ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error) {
+	return arcsOf(r.Base, dst, fs)
+}
+
+// Projection returns the projection of the Ideogram's Base.
+func (r *Ideogram) Projection() Projection { return projectionOf(r.Base) }
+
+// Plot calls DrawAt using the Ideogram's X and Y values as the drawing coordinates.
+func (r *Ideogram) Plot(ca draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&ca)
+	r.DrawAt(ca, vg.Point{trX(r.X), trY(r.Y)})
+}
+
+// GlyphBoxes returns a liberal glyphbox for the ideogram rendering.
+func (r *Ideogram) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	rad := r.Outer
+	if r.TextStyle.Color != nil && r.TextStyle.Font.Size != 0 && r.LabelRadius > rad {
+		rad = r.LabelRadius
+	}
+	return []plot.GlyphBox{{
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(rad),
+	}}
+}
@@ -75,6 +75,11 @@ type Labels struct {
 	// nil, DefaultPlacement is used.
 	Placement TextPlacement
 
+	// Rules specifies styling rules applied to each label after
+	// any style defined by the Labeler itself. If Rules is nil, no rules
+	// are applied.
+	Rules *Rules
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
@@ -82,8 +87,8 @@ type Labels struct {
 // NewLabels returns a Labels based on the parameters, first checking that the provided set of labels
 // are able to be rendered; an Arc or Highlight may only take a single label, otherwise the labels
 // must be a feat.Feature that can be found in the base ring. An error is returned if the labels are
-// not renderable. If base is an XYer, the returned base XY values are used to populate the Labels' X
-// and Y fields.
+// not renderable. Labels of features outside the display windows of base are not rendered. If base
+// is an XYer, the returned base XY values are used to populate the Labels' X and Y fields.
 func NewLabels(base Arcer, r vg.Length, ls ...Labeler) (*Labels, error) {
 	var b ArcOfer
 	switch base := base.(type) {
@@ -98,7 +103,7 @@ func NewLabels(base Arcer, r vg.Length, ls ...Labeler) (*Labels, error) {
 			default:
 				_, err = base.ArcOf(nil, nil)
 			}
-			if err != nil {
+			if err != nil && err != ErrOutsideWindows {
 				return nil, err
 			}
 		}
@@ -126,6 +131,7 @@ func NewLabels(base Arcer, r vg.Length, ls ...Labeler) (*Labels, error) {
 // DrawAt renders the text of a Labels at cen in the specified drawing area,
 // according to the Labels configuration.
 func (r *Labels) DrawAt(ca draw.Canvas, cen vg.Point) {
+	proj := projectionOf(r.Base)
 	for _, l := range r.Labels {
 		var sty draw.TextStyle
 		if ts, ok := l.(TextStyler); ok {
@@ -133,6 +139,21 @@ func (r *Labels) DrawAt(ca draw.Canvas, cen vg.Point) {
 		} else {
 			sty = r.TextStyle
 		}
+		if r.Rules != nil {
+			it := featureItem(nil)
+			switch l := l.(type) {
+			case locater:
+				it.Feature = l.location()
+			case feat.Feature:
+				it.Feature = l
+			}
+			it.Label = l.Label()
+			s, visible := r.Rules.apply(it, Style{Text: sty})
+			if !visible {
+				continue
+			}
+			sty = s.Text
+		}
 		if sty.Color == nil || sty.Font.Size == 0 {
 			continue
 		}
@@ -149,20 +170,23 @@ func (r *Labels) DrawAt(ca draw.Canvas, cen vg.Point) {
 		default:
 			arc, err = r.Base.ArcOf(nil, nil)
 		}
+		if err == ErrOutsideWindows {
+			continue
+		}
 		if err != nil {
 			panic(fmt.Sprint("rings: no arc for feature location:", err))
 		}
 
 		angle := arc.Theta + arc.Phi/2
-		pt := cen.Add(Rectangular(angle, r.Radius))
+		pt := cen.Add(proj.Point(angle, r.Radius))
 		var (
 			rot            Angle
 			xalign, yalign float64
 		)
 		if r.Placement == nil {
-			rot, xalign, yalign = DefaultPlacement(angle)
+			rot, xalign, yalign = DefaultPlacement(proj.Normal(angle))
 		} else {
-			rot, xalign, yalign = r.Placement(angle)
+			rot, xalign, yalign = r.Placement(proj.Normal(angle))
 		}
 		sty.XAlign = draw.XAlignment(xalign)
 		sty.YAlign = draw.YAlignment(yalign)
@@ -171,6 +195,9 @@ func (r *Labels) DrawAt(ca draw.Canvas, cen vg.Point) {
 	}
 }
 
+// Projection returns the projection of the Labels' Base.
+func (r *Labels) Projection() Projection { return projectionOf(r.Base) }
+
 // Plot calls DrawAt using the Labels' X and Y values as the drawing coordinates.
 func (r *Labels) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -180,12 +207,9 @@ func (r *Labels) Plot(ca draw.Canvas, plt *plot.Plot) {
 // GlyphBoxes returns a liberal glyphbox for the label rendering.
 func (r *Labels) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-r.Radius, -r.Radius},
-			Max: vg.Point{r.Radius, r.Radius},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(r.Radius),
 	}}
 }
 
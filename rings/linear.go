@@ -0,0 +1,35 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"gonum.org/v1/plot/vg"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// LinearArcs is an ArcOfer that lays out features along a horizontal line, allowing
+// the ring renderers to draw genome browser style tracks. The base arc of a LinearArcs
+// is mapped to the horizontal span from the rendering center to Length, and the radii
+// of renderers based on a LinearArcs specify heights above the rendering center.
+//
+// Angles in a LinearArcs serve only as linear coordinates, so they are not normalized
+// and angles that differ by complete turns are rendered at different positions.
+type LinearArcs struct {
+	Arcs
+
+	// Length is the horizontal length of the base arc.
+	Length vg.Length
+}
+
+// NewLinearArcs returns a LinearArcs that maps the provided features along the base arc with
+// fractional gaps around each feature specified by gap. The base arc is rendered with the
+// horizontal length specified by length.
+func NewLinearArcs(base Arcer, fs []feat.Feature, gap Gapper, length vg.Length) LinearArcs {
+	return LinearArcs{Arcs: spacedArcs(base, fs, gap, nil), Length: length}
+}
+
+// Projection returns the linear projection of the LinearArcs.
+func (a LinearArcs) Projection() Projection { return linear{base: a.Base, length: a.Length} }
@@ -34,20 +34,26 @@ type Links struct {
 	// is over-ridden if the Pair describing features is a LineStyler.
 	LineStyle draw.LineStyle
 
+	// Rules specifies styling rules applied to each link after
+	// any style defined by the pair itself. If Rules is nil, no rules
+	// are applied.
+	Rules *Rules
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
 
 // NewLinks returns a Links based on the parameters, first checking that the provided features
 // are able to be rendered. An error is returned if the features are not renderable. The ends of
-// a Links ring cannot be an Arc or a Highlight.
+// a Links ring cannot be an Arc or a Highlight. Pairs with a feature outside the display
+// windows of its end are not rendered.
 func NewLinks(fp []Pair, ends [2]ArcOfer, r [2]vg.Length) (*Links, error) {
 	for _, p := range fp {
 		for i, f := range p.Features() {
 			if f.End() < f.Start() {
 				return nil, errors.New("rings: inverted feature")
 			}
-			if _, err := ends[i].ArcOf(nil, f); err != nil {
+			if _, err := ends[i].ArcOf(nil, f); err != nil && err != ErrOutsideWindows {
 				return nil, err
 			}
 		}
@@ -69,9 +75,19 @@ func (r *Links) DrawAt(ca draw.Canvas, cen vg.Point) {
 	// Check if we have a Bézier and we want more than one segment in the curve.
 	bez := r.Bezier != nil && r.Bezier.Segments > 1
 
+	proj := [2]Projection{projectionOf(r.Ends[0]), projectionOf(r.Ends[1])}
 	var pa vg.Path
 loop:
 	for _, fp := range r.Set {
+		sty := Style{Line: r.LineStyle}
+		if ls, ok := fp.(LineStyler); ok {
+			sty.Line = ls.LineStyle()
+		}
+		sty, visible := r.Rules.apply(pairItem(fp), sty)
+		if !visible || sty.Line.Color == nil || sty.Line.Width == 0 {
+			continue
+		}
+
 		p := fp.Features()
 		loc := [2]feat.Feature{p[0].Location(), p[1].Location()}
 		var min, max [2]int
@@ -87,38 +103,33 @@ loop:
 			}
 
 			arc, err := r.Ends[j].ArcOf(f.Location(), f)
+			if err == ErrOutsideWindows {
+				continue loop
+			}
 			if err != nil {
 				panic(fmt.Sprint("rings: no arc for feature location:", err))
 			}
-			angles[j] = Normalize(arc.Theta)
+			angles[j] = normalizeIn(proj[j], arc.Theta)
 		}
 
 		pa = pa[:0]
-		pa.Move(cen.Add(Rectangular(angles[0], r.Radii[0])))
+		pa.Move(cen.Add(proj[0].Point(angles[0], r.Radii[0])))
 		// Bézier from angles[0]@radius[0] to angles[1]@radius[1] through
 		// r.Bezier if it is not nil and we wanted more than 1 segment;
 		// otherwise straight lines.
 		if bez {
 			b := bezier.New(
-				r.Bezier.ControlPoints(angles, r.Radii)...,
+				r.Bezier.controlPoints(proj, angles, r.Radii)...,
 			)
 			for i := 1; i <= r.Bezier.Segments; i++ {
 				pa.Line(cen.Add(b.Point(float64(i) / float64(r.Bezier.Segments))))
 			}
 		} else {
-			pa.Line(cen.Add(Rectangular(angles[1], r.Radii[1])))
+			pa.Line(cen.Add(proj[1].Point(angles[1], r.Radii[1])))
 		}
 
-		var sty draw.LineStyle
-		if ls, ok := fp.(LineStyler); ok {
-			sty = ls.LineStyle()
-		} else {
-			sty = r.LineStyle
-		}
-		if sty.Color != nil && sty.Width != 0 {
-			ca.SetLineStyle(sty)
-			ca.Stroke(pa)
-		}
+		ca.SetLineStyle(sty.Line)
+		ca.Stroke(pa)
 	}
 }
 
@@ -139,9 +150,14 @@ func (r *Links) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		rad = float64(r.Radii[1])
 	}
 
+	proj := [2]Projection{projectionOf(r.Ends[0]), projectionOf(r.Ends[1])}
+	_, isPolar := proj[0].(polar)
+	var ext vg.Rectangle
+
 	// If draw a Bézier we need to see if the radius is increased,
 	// so we mock the drawing, just keeping a record of the furthest
-	// distance from the origin. This may change to be more conservative.
+	// distance from the origin, or the extent of the curve when the
+	// projection is not polar. This may change to be more conservative.
 	if r.Bezier != nil && r.Bezier.Segments > 1 {
 	loop:
 		for _, fp := range r.Set {
@@ -160,18 +176,23 @@ func (r *Links) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 				}
 
 				arc, err := r.Ends[j].ArcOf(f.Location(), f)
+				if err == ErrOutsideWindows {
+					continue loop
+				}
 				if err != nil {
 					panic(fmt.Sprint("rings: no arc for feature location:", err))
 				}
-				angles[j] = Normalize(arc.Theta)
+				angles[j] = normalizeIn(proj[j], arc.Theta)
 			}
 
 			b := bezier.New(
-				r.Bezier.ControlPoints(angles, r.Radii)...,
+				r.Bezier.controlPoints(proj, angles, r.Radii)...,
 			)
 			for k := 0; k <= r.Bezier.Segments; k++ {
 				e := b.Point(float64(k) / float64(r.Bezier.Segments))
-				if d := math.Hypot(float64(e.X), float64(e.Y)); d > rad {
+				if !isPolar {
+					ext = union(ext, vg.Rectangle{Min: e, Max: e})
+				} else if d := math.Hypot(float64(e.X), float64(e.Y)); d > rad {
 					rad = d
 				}
 			}
@@ -179,11 +200,8 @@ func (r *Links) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	}
 
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{vg.Length(-rad), vg.Length(-rad)},
-			Max: vg.Point{vg.Length(rad), vg.Length(rad)},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: union(union(proj[0].Bounds(vg.Length(rad)), proj[1].Bounds(vg.Length(rad))), ext),
 	}}
 }
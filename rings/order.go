@@ -0,0 +1,295 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// Objective specifies the quantity reduced by Order.
+type Objective int
+
+const (
+	// Crossings specifies the number of pairs of crossing links.
+	Crossings Objective = iota
+
+	// ChordLength specifies the total chord length of links.
+	ChordLength
+)
+
+// Ordering is an arrangement of features returned by Order.
+type Ordering struct {
+	// Features holds the ordered features.
+	Features []feat.Feature
+
+	// Reversed indicates whether the feature at the corresponding
+	// index of Features should be displayed reversed.
+	Reversed []bool
+}
+
+// Windows returns a set of display windows, each covering the complete length of the
+// corresponding feature of the Ordering and oriented according to Reversed, suitable
+// for use with NewWindowedArcs.
+func (o Ordering) Windows() []feat.Feature {
+	ws := make([]feat.Feature, len(o.Features))
+	for i, f := range o.Features {
+		orient := feat.Forward
+		if fo, ok := f.(featureOrienter); ok && globalOrientation(fo) == feat.Reverse {
+			orient = feat.Reverse
+		}
+		if o.Reversed[i] {
+			orient = -orient
+		}
+		ws[i] = &Window{Loc: f, From: f.Start(), To: f.End(), Orient: orient}
+	}
+	return ws
+}
+
+// Order returns an ordering of the features in fs that reduces the objective measured over the
+// links described by fp. Links are treated as chords between the centers of their features, with
+// the features of fs placed around a circle in proportion to their lengths. If reverse is true,
+// features may also be reversed to further reduce the objective.
+//
+// The Features field of the returned Ordering may be passed directly to NewGappedArcs. Since
+// reversal depends on feature orientation, reversed features are rendered reversed only when
+// the Windows of the Ordering are passed to NewWindowedArcs.
+//
+// Order performs a greedy local search starting from the order of fs, so the returned ordering
+// is not guaranteed to be optimal. An error is returned if fs holds a feature more than once or
+// if a feature of fp is not held by a feature in fs.
+func Order(fs []feat.Feature, fp []Pair, obj Objective, reverse bool) (Ordering, error) {
+	idx := make(map[feat.Feature]int, len(fs))
+	o := orderer{obj: obj, lens: make([]float64, len(fs)), starts: make([]float64, len(fs))}
+	for i, f := range fs {
+		if _, ok := idx[f]; ok {
+			return Ordering{}, errors.New("rings: duplicate feature")
+		}
+		idx[f] = i
+		o.lens[i] = float64(f.Len())
+	}
+	o.links = make([][2]linkEnd, len(fp))
+	for i, p := range fp {
+		for j, f := range p.Features() {
+			e, ok := linkEndOf(f, idx)
+			if !ok {
+				return Ordering{}, errors.New("rings: feature not found")
+			}
+			o.links[i][j] = e
+		}
+	}
+
+	order := make([]int, len(fs))
+	for i := range order {
+		order[i] = i
+	}
+	rev := make([]bool, len(fs))
+
+	best := o.cost(order, rev)
+	for improved := true; improved && best > 0; {
+		improved = false
+		for i := range order {
+			for j := range order {
+				if j == i {
+					continue
+				}
+				move(order, i, j)
+				if c := o.cost(order, rev); c < best {
+					best = c
+					improved = true
+					break
+				}
+				move(order, j, i)
+			}
+		}
+		if !reverse {
+			continue
+		}
+		for i := range rev {
+			rev[i] = !rev[i]
+			if c := o.cost(order, rev); c < best {
+				best = c
+				improved = true
+				continue
+			}
+			rev[i] = !rev[i]
+		}
+	}
+
+	ord := Ordering{Features: make([]feat.Feature, len(fs)), Reversed: make([]bool, len(fs))}
+	for i, j := range order {
+		ord.Features[i] = fs[j]
+		ord.Reversed[i] = rev[j]
+	}
+	return ord, nil
+}
+
+// move moves the element of s at i to j, shifting the intervening elements.
+func move(s []int, i, j int) {
+	v := s[i]
+	if i < j {
+		copy(s[i:j], s[i+1:j+1])
+	} else {
+		copy(s[j+1:i+1], s[j:i])
+	}
+	s[j] = v
+}
+
+// linkEnd is the position of a link end as a fraction of the length of the
+// feature, with index seg, that holds it.
+type linkEnd struct {
+	seg int
+	off float64
+}
+
+// linkEndOf returns the linkEnd of f within the features indexed by idx.
+func linkEndOf(f feat.Feature, idx map[feat.Feature]int) (linkEnd, bool) {
+	if i, ok := idx[f]; ok {
+		return linkEnd{seg: i, off: 0.5}, true
+	}
+	for c := f; c != nil; c = c.Location() {
+		loc := c.Location()
+		i, ok := idx[loc]
+		if !ok {
+			continue
+		}
+		off := 0.5
+		if loc.Len() != 0 {
+			off = (float64(c.Start()+c.End())/2 - float64(loc.Start())) / float64(loc.Len())
+		}
+		if fo, ok := loc.(featureOrienter); ok && globalOrientation(fo) == feat.Reverse {
+			off = 1 - off
+		}
+		return linkEnd{seg: i, off: off}, true
+	}
+	return linkEnd{}, false
+}
+
+// orderer holds the state for evaluating feature orderings.
+type orderer struct {
+	obj    Objective
+	lens   []float64
+	links  [][2]linkEnd
+	starts []float64
+
+	chords chords
+	ends   []float64
+	tree   []int
+}
+
+// pos returns the position of e given the current feature starts and reversals.
+func (o *orderer) pos(e linkEnd, rev []bool) float64 {
+	off := e.off
+	if rev[e.seg] {
+		off = 1 - off
+	}
+	return o.starts[e.seg] + off*o.lens[e.seg]
+}
+
+// cost returns the objective value for the provided order and reversals.
+func (o *orderer) cost(order []int, rev []bool) float64 {
+	var total float64
+	for _, i := range order {
+		o.starts[i] = total
+		total += o.lens[i]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	switch o.obj {
+	case ChordLength:
+		var sum float64
+		for _, l := range o.links {
+			d := math.Abs(o.pos(l[0], rev)-o.pos(l[1], rev)) / total
+			sum += 2 * math.Sin(math.Pi*d)
+		}
+		return sum
+	case Crossings:
+		return float64(o.crossings(rev))
+	default:
+		panic("rings: unknown objective")
+	}
+}
+
+// crossings returns the number of pairs of crossing links. Two chords cross when exactly one
+// end of one lies strictly between the ends of the other. Chords are processed in order of their
+// lower end, counting the earlier chords with upper ends strictly within the current chord.
+// Chords of zero length cannot cross and are ignored.
+func (o *orderer) crossings(rev []bool) int {
+	o.chords = o.chords[:0]
+	o.ends = o.ends[:0]
+	for _, l := range o.links {
+		a, b := o.pos(l[0], rev), o.pos(l[1], rev)
+		if a == b {
+			continue
+		}
+		if a > b {
+			a, b = b, a
+		}
+		o.chords = append(o.chords, [2]float64{a, b})
+		o.ends = append(o.ends, a, b)
+	}
+	sort.Sort(o.chords)
+	sort.Float64s(o.ends)
+
+	if cap(o.tree) < len(o.ends)+1 {
+		o.tree = make([]int, len(o.ends)+1)
+	}
+	o.tree = o.tree[:len(o.ends)+1]
+	for i := range o.tree {
+		o.tree[i] = 0
+	}
+
+	var n int
+	for i := 0; i < len(o.chords); {
+		j := i
+		for j < len(o.chords) && o.chords[j][0] == o.chords[i][0] {
+			j++
+		}
+		for _, c := range o.chords[i:j] {
+			// Ends are indexed in the tree by the position of their first
+			// occurrence in o.ends, so this counts the inserted upper ends
+			// strictly between the ends of c.
+			n += o.sum(sort.SearchFloat64s(o.ends, c[1])) - o.sum(searchAfter(o.ends, c[0]))
+		}
+		for _, c := range o.chords[i:j] {
+			o.add(sort.SearchFloat64s(o.ends, c[1]) + 1)
+		}
+		i = j
+	}
+	return n
+}
+
+// searchAfter returns the number of elements of the sorted slice a that are less than or equal to x.
+func searchAfter(a []float64, x float64) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// add increments the count at the 1-based Fenwick tree index i.
+func (o *orderer) add(i int) {
+	for ; i < len(o.tree); i += i & -i {
+		o.tree[i]++
+	}
+}
+
+// sum returns the total count at Fenwick tree indices up to and including i.
+func (o *orderer) sum(i int) int {
+	var s int
+	for ; i > 0; i -= i & -i {
+		s += o.tree[i]
+	}
+	return s
+}
+
+// chords sorts a set of chords by ascending lower end.
+type chords [][2]float64
+
+func (c chords) Len() int           { return len(c) }
+func (c chords) Less(i, j int) bool { return c[i][0] < c[j][0] }
+func (c chords) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
@@ -0,0 +1,153 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"math"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// Projection describes the mapping of ring coordinates, an angle and a radius, to
+// the drawing plane.
+type Projection interface {
+	// Point returns the location of the ring coordinate (theta, r)
+	// relative to the rendering center.
+	Point(theta Angle, r vg.Length) vg.Point
+
+	// Arc adds a path following the ring at radius r from theta
+	// through the sweep phi to pa, where cen is the rendering center.
+	// The path is added in the same way as by vg.Path's Arc method.
+	Arc(pa *vg.Path, cen vg.Point, r vg.Length, theta, phi Angle)
+
+	// Normal returns the direction in the drawing plane perpendicular
+	// to the ring at theta, pointing toward increasing radius.
+	Normal(theta Angle) Angle
+
+	// Bounds returns a rectangle relative to the rendering center that
+	// contains all ring coordinates with radii up to r.
+	Bounds(r vg.Length) vg.Rectangle
+}
+
+// Projector is a type that can specify the projection used to render rings
+// based on it. Renderers use the Projection of their base if it is a Projector,
+// and polar coordinates otherwise.
+type Projector interface {
+	Projection() Projection
+}
+
+// projectionOf returns the Projection of b if it is a Projector with a non-nil
+// Projection and the polar projection otherwise.
+func projectionOf(b interface{}) Projection {
+	if p, ok := b.(Projector); ok {
+		if proj := p.Projection(); proj != nil {
+			return proj
+		}
+	}
+	return polar{}
+}
+
+// polar is the default polar coordinate projection.
+type polar struct{}
+
+func (polar) Point(theta Angle, r vg.Length) vg.Point { return Rectangular(theta, r) }
+func (polar) Arc(pa *vg.Path, cen vg.Point, r vg.Length, theta, phi Angle) {
+	pa.Arc(cen, r, float64(theta), float64(phi))
+}
+func (polar) Normal(theta Angle) Angle { return theta }
+func (polar) Bounds(r vg.Length) vg.Rectangle {
+	return vg.Rectangle{Min: vg.Point{X: -r, Y: -r}, Max: vg.Point{X: r, Y: r}}
+}
+
+// spiralStep is the maximum angular step used to approximate a spiral path.
+const spiralStep = Complete / 360
+
+// spiral is an Archimedean spiral projection.
+type spiral struct {
+	base  Arc
+	pitch vg.Length
+}
+
+func (s spiral) Point(theta Angle, r vg.Length) vg.Point {
+	return Spiral(theta, s.base.Theta, r, s.pitch)
+}
+
+func (s spiral) Arc(pa *vg.Path, cen vg.Point, r vg.Length, theta, phi Angle) {
+	n := int(math.Ceil(math.Abs(float64(phi / spiralStep))))
+	for i := 0; i <= n; i++ {
+		var a Angle
+		if n != 0 {
+			a = theta + phi*Angle(i)/Angle(n)
+		} else {
+			a = theta
+		}
+		pa.Line(cen.Add(s.Point(a, r)))
+	}
+}
+
+func (s spiral) Normal(theta Angle) Angle { return theta }
+
+func (s spiral) Bounds(r vg.Length) vg.Rectangle {
+	r += vg.Length(math.Abs(float64(s.pitch) * float64(s.base.Phi/Complete)))
+	return vg.Rectangle{Min: vg.Point{X: -r, Y: -r}, Max: vg.Point{X: r, Y: r}}
+}
+
+// linear is a projection onto a horizontal line, mapping the base arc to the
+// span from zero to length and radii to heights above the line.
+type linear struct {
+	base   Arc
+	length vg.Length
+}
+
+func (l linear) Point(theta Angle, r vg.Length) vg.Point {
+	return vg.Point{X: l.x(theta), Y: r}
+}
+
+func (l linear) Arc(pa *vg.Path, cen vg.Point, r vg.Length, theta, phi Angle) {
+	pa.Line(cen.Add(l.Point(theta, r)))
+	pa.Line(cen.Add(l.Point(theta+phi, r)))
+}
+
+func (l linear) Normal(theta Angle) Angle { return Complete / 4 }
+
+func (l linear) Bounds(r vg.Length) vg.Rectangle {
+	var b vg.Rectangle
+	if l.length < 0 {
+		b.Min.X = l.length
+	} else {
+		b.Max.X = l.length
+	}
+	if r < 0 {
+		b.Min.Y = r
+	} else {
+		b.Max.Y = r
+	}
+	return b
+}
+
+// x returns the horizontal position of theta.
+func (l linear) x(theta Angle) vg.Length {
+	if l.base.Phi == 0 {
+		return 0
+	}
+	return vg.Length((theta-l.base.Theta)/l.base.Phi) * l.length
+}
+
+// normalizeIn returns theta normalized if proj is the polar projection. Other
+// projections distinguish angles that differ by complete turns.
+func normalizeIn(proj Projection, theta Angle) Angle {
+	if _, ok := proj.(polar); ok {
+		return Normalize(theta)
+	}
+	return theta
+}
+
+// union returns the smallest rectangle containing both a and b.
+func union(a, b vg.Rectangle) vg.Rectangle {
+	return vg.Rectangle{
+		Min: vg.Point{X: vg.Length(math.Min(float64(a.Min.X), float64(b.Min.X))), Y: vg.Length(math.Min(float64(a.Min.Y), float64(b.Min.Y)))},
+		Max: vg.Point{X: vg.Length(math.Max(float64(a.Max.X), float64(b.Max.X))), Y: vg.Length(math.Max(float64(a.Max.Y), float64(b.Max.Y)))},
+	}
+}
@@ -69,20 +69,26 @@ type Ribbons struct {
 	// Bézier curves if the Pair is a LineStyler.
 	LineStyle draw.LineStyle
 
+	// Rules specifies styling rules applied to each ribbon after
+	// any style defined by the pair itself. If Rules is nil, no rules
+	// are applied.
+	Rules *Rules
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
 
 // NewRibbons returns a Ribbons based on the parameters, first checking that the provided features
 // are able to be rendered. An error is returned if the features are not renderable. The ends of
-// a Ribbons ring cannot be an Arc or a Highlight.
+// a Ribbons ring cannot be an Arc or a Highlight. Pairs with a feature outside the display
+// windows of its end are not rendered.
 func NewRibbons(fp []Pair, ends [2]ArcOfer, r [2]vg.Length) (*Ribbons, error) {
 	for _, p := range fp {
 		for i, f := range p.Features() {
 			if f.End() < f.Start() {
 				return nil, errors.New("rings: inverted feature")
 			}
-			if _, err := ends[i].ArcOf(nil, f); err != nil {
+			if _, err := ends[i].ArcOf(nil, f); err != nil && err != ErrOutsideWindows {
 				return nil, err
 			}
 		}
@@ -171,6 +177,18 @@ func (r *Ribbons) DrawAt(ca draw.Canvas, cen vg.Point) {
 	var pa vg.Path
 loop:
 	for _, fp := range r.Set {
+		sty := Style{Fill: r.Color, Line: r.LineStyle}
+		if c, ok := fp.(FillColorer); ok {
+			sty.Fill = c.FillColor()
+		}
+		if ls, ok := fp.(LineStyler); ok {
+			sty.Line = ls.LineStyle()
+		}
+		sty, visible := r.Rules.apply(pairItem(fp), sty)
+		if !visible {
+			continue
+		}
+
 		p := fp.Features()
 		var min, max [2]int
 		for j, loc := range [2]feat.Feature{p[0].Location(), p[1].Location()} {
@@ -187,6 +205,9 @@ loop:
 			}
 
 			arc, err := r.Ends[j].ArcOf(f.Location(), f)
+			if err == ErrOutsideWindows {
+				continue loop
+			}
 			if err != nil {
 				panic(fmt.Sprint("rings: no arc for feature location:", err))
 			}
@@ -225,18 +246,12 @@ loop:
 			}
 		}
 
-		var col color.Color
-		if c, ok := fp.(FillColorer); ok {
-			col = c.FillColor()
-		} else {
-			col = r.Color
-		}
-		if col != nil {
-			ca.SetColor(col)
+		if sty.Fill != nil {
+			ca.SetColor(sty.Fill)
 			ca.Fill(pa)
 		}
 
-		if ls, ok := fp.(LineStyler); ok || (r.LineStyle.Color != nil && r.LineStyle.Width != 0) {
+		if sty.Line.Color != nil && sty.Line.Width != 0 {
 			// Change Arc vg.PathComps to Move vg.PathComps where necessary.
 			for j, rad := range r.Radii {
 				if _, ok := p[j].(LineStyler); ok {
@@ -249,16 +264,8 @@ loop:
 				}
 			}
 
-			var sty draw.LineStyle
-			if ok {
-				sty = ls.LineStyle()
-			} else {
-				sty = r.LineStyle
-			}
-			if sty.Color != nil && sty.Width != 0 {
-				ca.SetLineStyle(sty)
-				ca.Stroke(pa)
-			}
+			ca.SetLineStyle(sty.Line)
+			ca.Stroke(pa)
 		}
 
 		// Draw feature ends according to the feature's linestyle if it has one.
@@ -316,6 +323,9 @@ func (r *Ribbons) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 				}
 
 				arc, err := r.Ends[j].ArcOf(f.Location(), f)
+				if err == ErrOutsideWindows {
+					continue loop
+				}
 				if err != nil {
 					panic(fmt.Sprint("rings: no arc for feature location:", err))
 				}
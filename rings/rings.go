@@ -58,6 +58,13 @@ type FillColorer interface {
 	FillColor() color.Color
 }
 
+// GlyphStyler is a type that can define the glyph style of its ith score value. For the purposes
+// of the rings package the glyphs of a GlyphStyler that returns a GlyphStyle with a nil Color, a nil
+// Shape or a Radius of 0 are not rendered.
+type GlyphStyler interface {
+	GlyphStyle(i int) draw.GlyphStyle
+}
+
 // XYer is a type that returns its x and y coordinates.
 type XYer interface {
 	XY() (x, y float64)
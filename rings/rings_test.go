@@ -11,15 +11,19 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 
 	"github.com/biogo/biogo/feat"
+	"github.com/biogo/biogo/feat/gene"
+	"github.com/biogo/biogo/feat/genome"
 	"github.com/biogo/graphics/rings"
 
 	"gopkg.in/check.v1"
@@ -2552,6 +2556,13 @@ func (s *S) TestSail(c *check.C) {
 	}
 }
 
+// linearChr returns a chromosome of length 100 and a linear base rendering it
+// from x=0 to x=100, with radii rendered as y coordinates.
+func linearChr() (*fs, rings.LinearArcs) {
+	chr := &fs{start: 0, end: 100, name: "chr"}
+	return chr, rings.NewLinearArcs(rings.Arc{0, 100}, []feat.Feature{chr}, rings.UniformGap(0), 100)
+}
+
 // makeScorers returns n Scorers each with m scores.
 func makeScorers(f *fs, n, m int, fn func(i, j int) float64) []rings.Scorer {
 	s := make([]rings.Scorer, n)
@@ -4421,3 +4432,2245 @@ func (s *S) TestScoresAxis(c *check.C) {
 		}
 	}
 }
+
+const tol = 1e-12
+
+func angleEquals(a, b rings.Angle) bool { return math.Abs(float64(a-b)) < tol }
+
+func arcEquals(a, b rings.Arc) bool {
+	return angleEquals(a.Theta, b.Theta) && angleEquals(a.Phi, b.Phi)
+}
+
+func (s *S) TestArcAlgebra(c *check.C) {
+	const pi = rings.Complete / 2
+	for i, t := range []struct {
+		arc   rings.Arc
+		alpha rings.Angle
+		want  bool
+	}{
+		{rings.Arc{3 * pi / 2, pi}, 0, true},
+		{rings.Arc{3 * pi / 2, pi}, pi / 4, true},
+		{rings.Arc{3 * pi / 2, pi}, pi, false},
+		{rings.Arc{pi / 2, -pi}, 0, true},
+		{rings.Arc{pi / 2, -pi}, -pi / 2, true},
+		{rings.Arc{pi / 2, -pi}, pi, false},
+		{rings.Arc{1, rings.Complete}, 0, true},
+		{rings.Arc{1, 0}, 1 + rings.Complete, true},
+	} {
+		c.Check(t.arc.Contains(t.alpha), check.Equals, t.want, check.Commentf("Test %d", i))
+	}
+
+	checkArcs := func(got, want []rings.Arc, i int, op string) {
+		c.Assert(len(got), check.Equals, len(want), check.Commentf("Test %s %d: %v", op, i, got))
+		for j := range got {
+			c.Check(arcEquals(got[j], want[j]), check.Equals, true, check.Commentf("Test %s %d: %v != %v", op, i, got, want))
+		}
+	}
+	for i, t := range []struct {
+		a, b      rings.Arc
+		intersect []rings.Arc
+		union     []rings.Arc
+		subtract  []rings.Arc
+	}{
+		{
+			a:         rings.Arc{3 * pi / 2, pi},
+			b:         rings.Arc{0, pi},
+			intersect: []rings.Arc{{2 * pi, pi / 2}},
+			union:     []rings.Arc{{3 * pi / 2, 3 * pi / 2}},
+			subtract:  []rings.Arc{{3 * pi / 2, pi / 2}},
+		},
+		{
+			a:         rings.Arc{0, 3 * pi / 2},
+			b:         rings.Arc{pi, 3 * pi / 2},
+			intersect: []rings.Arc{{0, pi / 2}, {pi, pi / 2}},
+			union:     []rings.Arc{{0, 2 * pi}},
+			subtract:  []rings.Arc{{pi / 2, pi / 2}},
+		},
+		{
+			a:         rings.Arc{pi / 2, -pi},
+			b:         rings.Arc{0, pi},
+			intersect: []rings.Arc{{pi / 2, -pi / 2}},
+			union:     []rings.Arc{{pi, -3 * pi / 2}},
+			subtract:  []rings.Arc{{0, -pi / 2}},
+		},
+		{
+			a:         rings.Arc{0, pi / 2},
+			b:         rings.Arc{pi, pi / 2},
+			intersect: []rings.Arc{},
+			union:     []rings.Arc{{0, pi / 2}, {pi, pi / 2}},
+			subtract:  []rings.Arc{{0, pi / 2}},
+		},
+		{
+			a:         rings.Arc{0, pi},
+			b:         rings.Arc{-pi / 2, pi},
+			intersect: []rings.Arc{{0, pi / 2}},
+			union:     []rings.Arc{{-pi / 2, 3 * pi / 2}},
+			subtract:  []rings.Arc{{pi / 2, pi / 2}},
+		},
+		{
+			a:         rings.Arc{0, pi},
+			b:         rings.Arc{pi / 4, pi / 2},
+			intersect: []rings.Arc{{pi / 4, pi / 2}},
+			union:     []rings.Arc{{0, pi}},
+			subtract:  []rings.Arc{{0, pi / 4}, {3 * pi / 4, pi / 4}},
+		},
+		{
+			a:         rings.Arc{0, 2 * pi},
+			b:         rings.Arc{pi / 2, pi},
+			intersect: []rings.Arc{{pi / 2, pi}},
+			union:     []rings.Arc{{0, 2 * pi}},
+			subtract:  []rings.Arc{{-pi / 2, pi}},
+		},
+		{
+			a:         rings.Arc{pi, -pi},
+			b:         rings.Arc{pi / 2, pi},
+			intersect: []rings.Arc{{pi, -pi / 2}},
+			union:     []rings.Arc{{3 * pi / 2, -3 * pi / 2}},
+			subtract:  []rings.Arc{{pi / 2, -pi / 2}},
+		},
+		{
+			a:         rings.Arc{3 * pi / 2, pi},
+			b:         rings.Arc{0, -2 * pi},
+			intersect: []rings.Arc{{3 * pi / 2, pi}},
+			union:     []rings.Arc{{3 * pi / 2, 2 * pi}},
+			subtract:  []rings.Arc{},
+		},
+	} {
+		checkArcs(t.a.Intersect(t.b), t.intersect, i, "intersect")
+		checkArcs(t.a.Union(t.b), t.union, i, "union")
+		checkArcs(t.a.Subtract(t.b), t.subtract, i, "subtract")
+	}
+
+	checkArcs(rings.Arc{3 * pi / 2, pi}.Split(0, pi, 7*pi/4, 2*pi), []rings.Arc{{3 * pi / 2, pi / 4}, {7 * pi / 4, pi / 4}, {2 * pi, pi / 2}}, 0, "split")
+	checkArcs(rings.Arc{0, -pi}.Split(-pi/2), []rings.Arc{{0, -pi / 2}, {-pi / 2, -pi / 2}}, 1, "split")
+	checkArcs(rings.Arc{0, pi}.Split(), []rings.Arc{{0, pi}}, 2, "split")
+}
+
+func (s *S) TestZoomedArcs(c *check.C) {
+	chr := []feat.Feature{
+		&fs{start: 0, end: 1000, name: "chr1"},
+		&fs{start: 0, end: 500, name: "chr2"},
+		&fs{start: 0, end: 250, name: "chr3"},
+	}
+	base := rings.Arc{rings.Complete / 4, rings.Complete * rings.Clockwise}
+
+	// No zoom matches NewGappedArcs.
+	za, err := rings.NewZoomedArcs(base, chr, 0.01, nil)
+	c.Assert(err, check.Equals, nil)
+	ga := rings.NewGappedArcs(base, chr, 0.01)
+	for _, f := range chr {
+		got, err := za.ArcOf(f, nil)
+		c.Check(err, check.Equals, nil)
+		want, _ := ga.ArcOf(f, nil)
+		c.Check(arcEquals(got, want), check.Equals, true, check.Commentf("got:%v want:%v", got, want))
+	}
+
+	// Zoom a region and a complete feature.
+	za, err = rings.NewZoomedArcs(base, chr, 0.01, []rings.Zoom{
+		{Feature: chr[0], Start: 100, End: 200, Scale: 10},
+		{Feature: chr[2], Scale: 2},
+	})
+	c.Assert(err, check.Equals, nil)
+
+	var total rings.Angle
+	for _, f := range chr {
+		a, err := za.ArcOf(f, nil)
+		c.Check(err, check.Equals, nil)
+		total += a.Phi
+	}
+	c.Check(angleEquals(total, base.Phi*(1-0.01*3)), check.Equals, true, check.Commentf("total sweep: %v", total))
+
+	unzoomed, err := za.ArcOf(chr[0], &fs{start: 0, end: 100, location: chr[0]})
+	c.Check(err, check.Equals, nil)
+	zoomed, err := za.ArcOf(chr[0], &fs{start: 100, end: 200, location: chr[0]})
+	c.Check(err, check.Equals, nil)
+	c.Check(angleEquals(zoomed.Phi, 10*unzoomed.Phi), check.Equals, true)
+	c.Check(angleEquals(zoomed.Theta, unzoomed.Theta+unzoomed.Phi), check.Equals, true)
+
+	across, err := za.ArcOf(chr[0], &fs{start: 150, end: 300, location: chr[0]})
+	c.Check(err, check.Equals, nil)
+	c.Check(angleEquals(across.Phi, 5*unzoomed.Phi+unzoomed.Phi), check.Equals, true)
+
+	whole2, _ := za.ArcOf(chr[1], nil)
+	whole3, _ := za.ArcOf(chr[2], nil)
+	c.Check(angleEquals(whole3.Phi, whole2.Phi), check.Equals, true)
+
+	_, err = za.ArcOf(nil, &fs{start: 1200, end: 1300, location: chr[0]})
+	c.Check(err, check.Not(check.Equals), nil)
+
+	for _, z := range [][]rings.Zoom{
+		{{Feature: &fs{end: 10}, Scale: 2}},
+		{{Feature: chr[0], Scale: 0}},
+		{{Feature: chr[0], Start: 900, End: 1100, Scale: 2}},
+		{{Feature: chr[0], Start: 100, End: 200, Scale: 2}, {Feature: chr[0], Start: 150, End: 250, Scale: 2}},
+	} {
+		_, err = rings.NewZoomedArcs(base, chr, 0.01, z)
+		c.Check(err, check.Not(check.Equals), nil)
+	}
+}
+
+func (s *S) TestWindowedArcs(c *check.C) {
+	chr := []feat.Feature{
+		&fs{start: 0, end: 50000000, name: "chr1"},
+		&fs{start: 0, end: 10000000, name: "chr3"},
+	}
+	ws := []feat.Feature{
+		&rings.Window{Loc: chr[1], From: 0, To: 5000000, Orient: feat.Reverse},
+		&rings.Window{Loc: chr[0], From: 10000000, To: 40000000},
+		&rings.Window{Loc: chr[0], From: 45000000, To: 50000000},
+	}
+	base := rings.Arc{0, rings.Complete}
+	a, err := rings.NewWindowedArcs(base, ws, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(ws[0].Name(), check.Equals, "chr3:0-5000000")
+
+	var theta rings.Angle
+	for _, w := range ws {
+		arc, err := a.ArcOf(w.Location(), w)
+		c.Check(err, check.Equals, nil)
+		want, err := a.ArcOf(nil, w)
+		c.Check(err, check.Equals, nil)
+		c.Check(arcEquals(arc, want), check.Equals, true, check.Commentf("got:%v want:%v", arc, want))
+		c.Check(angleEquals(rings.Angle(math.Abs(float64(arc.Phi))), rings.Complete*rings.Angle(w.Len())/40e6), check.Equals, true)
+		if arc.Phi < 0 {
+			arc.Theta, arc.Phi = arc.Theta+arc.Phi, -arc.Phi
+		}
+		c.Check(angleEquals(arc.Theta, theta), check.Equals, true, check.Commentf("unexpected window order"))
+		theta += arc.Phi
+	}
+
+	// Features from the original location map into windows.
+	f := &fs{start: 1000000, end: 2000000, location: chr[1]}
+	arc, err := a.ArcOf(f.Location(), f)
+	c.Check(err, check.Equals, nil)
+	c.Check(arcEquals(arc, rings.Arc{rings.Complete * 4 / 40, -rings.Complete / 40}), check.Equals, true, check.Commentf("got:%v", arc))
+
+	f = &fs{start: 20000000, end: 21000000, location: chr[0]}
+	arc, err = a.ArcOf(nil, f)
+	c.Check(err, check.Equals, nil)
+	c.Check(arcEquals(arc, rings.Arc{rings.Complete * 15 / 40, rings.Complete / 40}), check.Equals, true, check.Commentf("got:%v", arc))
+
+	// Features partially in a window are clipped.
+	f = &fs{start: 39000000, end: 42000000, location: chr[0]}
+	arc, err = a.ArcOf(nil, f)
+	c.Check(err, check.Equals, nil)
+	c.Check(arcEquals(arc, rings.Arc{rings.Complete * 34 / 40, rings.Complete / 40}), check.Equals, true, check.Commentf("got:%v", arc))
+	arcs, err := a.ArcsOf(nil, []feat.Feature{f, &fs{start: 4000000, end: 6000000, location: chr[1]}})
+	c.Check(err, check.Equals, nil)
+	c.Assert(len(arcs), check.Equals, 2)
+	c.Check(arcEquals(arcs[0], rings.Arc{rings.Complete * 34 / 40, rings.Complete / 40}), check.Equals, true, check.Commentf("got:%v", arcs[0]))
+	c.Check(arcEquals(arcs[1], rings.Arc{rings.Complete / 40, -rings.Complete / 40}), check.Equals, true, check.Commentf("got:%v", arcs[1]))
+
+	// Features outside all windows are reported.
+	for _, f := range []feat.Feature{
+		&fs{start: 1000000, end: 2000000, location: chr[0]},
+		&fs{start: 6000000, end: 7000000, location: chr[1]},
+	} {
+		_, err = a.ArcOf(f.Location(), f)
+		c.Check(err, check.Equals, rings.ErrOutsideWindows)
+	}
+
+	// Features spanning disjoint windows are not mapped.
+	f = &fs{start: 30000000, end: 46000000, location: chr[0]}
+	_, err = a.ArcOf(f.Location(), f)
+	c.Check(err, check.Not(check.Equals), nil)
+
+	for _, ws := range [][]feat.Feature{
+		{&rings.Window{Loc: chr[0], From: 10, To: 5}},
+		{&rings.Window{Loc: chr[1], From: 0, To: 20000000}},
+		{&rings.Window{Loc: chr[0], From: 0, To: 20}, &rings.Window{Loc: chr[0], From: 10, To: 30}},
+		{&fs{start: 0, end: 10}},
+	} {
+		_, err = rings.NewWindowedArcs(base, ws, 0)
+		c.Check(err, check.Not(check.Equals), nil)
+	}
+}
+
+// windowedChr returns a chromosome of length 100 and a SegmentedArcs displaying only
+// positions 0 to 50 of it.
+func windowedChr(c *check.C) (*fs, rings.SegmentedArcs) {
+	chr := &fs{start: 0, end: 100, name: "chr"}
+	win, err := rings.NewWindowedArcs(rings.Arc{0, rings.Complete}, []feat.Feature{
+		&rings.Window{Loc: chr, From: 0, To: 50},
+	}, 0)
+	c.Assert(err, check.Equals, nil)
+	return chr, win
+}
+
+// drawActions returns the actions recorded when p is drawn.
+func drawActions(p interface {
+	DrawAt(draw.Canvas, vg.Point)
+}) []interface{} {
+	tc := &canvas{dpi: defaultDPI}
+	p.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	return tc.actions
+}
+
+func (s *S) TestBlocksOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	in := &fs{start: 10, end: 20, name: "in", location: chr}
+	out := &fs{start: 60, end: 70, name: "out", location: chr}
+
+	var want []interface{}
+	for _, set := range [][]feat.Feature{{in}, {in, out}} {
+		b, err := rings.NewBlocks(set, win, 10, 20)
+		c.Assert(err, check.Equals, nil)
+		b.Color = color.Black
+		got := drawActions(b)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+	_, err := rings.NewSpacedBlocks([]feat.Feature{in, out}, win, 10, 20, rings.UniformGap(0))
+	c.Check(err, check.Equals, nil)
+}
+
+func (s *S) TestScoresOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	in := &fs{start: 10, end: 20, name: "in", location: chr, scores: []float64{1}}
+	out := &fs{start: 60, end: 70, name: "out", location: chr, scores: []float64{2}}
+
+	r := &recRenderer{}
+	sc, err := rings.NewScores([]rings.Scorer{in, out}, win, 10, 20, r)
+	c.Assert(err, check.Equals, nil)
+	sc.DrawAt(draw.Canvas{}, vg.Point{})
+	c.Check(r.scorers, check.DeepEquals, []rings.Scorer{in})
+
+	// Bins outside the windows are not rendered.
+	sc.Bins = &rings.Bins{Width: 50}
+	sc.DrawAt(draw.Canvas{}, vg.Point{})
+	c.Assert(r.scorers, check.HasLen, 1)
+	c.Check(r.scorers[0].Name(), check.Equals, "chr:0-50")
+}
+
+func (s *S) TestLinksOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	sty := plotter.DefaultLineStyle
+	a := &fs{start: 10, end: 20, name: "a", location: chr, style: sty}
+	b := &fs{start: 30, end: 40, name: "b", location: chr, style: sty}
+	out := &fs{start: 60, end: 70, name: "out", location: chr, style: sty}
+
+	var want []interface{}
+	for _, set := range [][]rings.Pair{{fp{feats: [2]*fs{a, b}, sty: sty}}, {fp{feats: [2]*fs{a, b}, sty: sty}, fp{feats: [2]*fs{a, out}, sty: sty}}} {
+		l, err := rings.NewLinks(set, [2]rings.ArcOfer{win, win}, [2]vg.Length{10, 10})
+		c.Assert(err, check.Equals, nil)
+		got := drawActions(l)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestRibbonsOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	sty := plotter.DefaultLineStyle
+	a := &fs{start: 10, end: 20, name: "a", location: chr, style: sty}
+	b := &fs{start: 30, end: 40, name: "b", location: chr, style: sty}
+	out := &fs{start: 60, end: 70, name: "out", location: chr, style: sty}
+
+	var want []interface{}
+	for _, set := range [][]rings.Pair{{fp{feats: [2]*fs{a, b}}}, {fp{feats: [2]*fs{a, b}}, fp{feats: [2]*fs{a, out}}}} {
+		r, err := rings.NewRibbons(set, [2]rings.ArcOfer{win, win}, [2]vg.Length{10, 10})
+		c.Assert(err, check.Equals, nil)
+		r.Color = color.Black
+		got := drawActions(r)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestLabelsOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	in := &fs{start: 10, end: 20, name: "in", location: chr}
+	out := &fs{start: 60, end: 70, name: "out", location: chr}
+	font, err := vg.MakeFont("Helvetica", 10)
+	c.Assert(err, check.Equals, nil)
+
+	var want []interface{}
+	for _, set := range [][]feat.Feature{{in}, {in, out}} {
+		l, err := rings.NewLabels(win, 30, rings.NameLabels(set)...)
+		c.Assert(err, check.Equals, nil)
+		l.TextStyle = draw.TextStyle{Color: color.Black, Font: font}
+		got := drawActions(l)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestSpokesOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	sty := plotter.DefaultLineStyle
+	in := &fs{start: 10, end: 11, name: "in", location: chr, style: sty}
+	out := &fs{start: 60, end: 61, name: "out", location: chr, style: sty}
+
+	var want []interface{}
+	for _, set := range [][]feat.Feature{{in}, {in, out}} {
+		sp, err := rings.NewSpokes(set, win, 10, 20)
+		c.Assert(err, check.Equals, nil)
+		sp.LineStyle = sty
+		got := drawActions(sp)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestSailOutsideWindows(c *check.C) {
+	chr, win := windowedChr(c)
+	a := &fs{start: 10, end: 20, name: "a", location: chr}
+	b := &fs{start: 30, end: 40, name: "b", location: chr}
+	out := &fs{start: 60, end: 70, name: "out", location: chr}
+
+	var want []interface{}
+	for _, set := range [][]feat.Feature{{a, b}, {a, b, out}} {
+		sl, err := rings.NewSail(set, win, 10)
+		c.Assert(err, check.Equals, nil)
+		sl.Color = color.Black
+		got := drawActions(sl)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestIdeogramOutsideWindows(c *check.C) {
+	chr := &genome.Chromosome{Chr: "chr1", Length: 100}
+	win, err := rings.NewWindowedArcs(rings.Arc{0, rings.Complete}, []feat.Feature{
+		&rings.Window{Loc: chr, From: 0, To: 50},
+	}, 0)
+	c.Assert(err, check.Equals, nil)
+	in := &genome.Band{Band: "p1", Chr: chr, StartPos: 0, EndPos: 40, Giemsa: "gneg"}
+	out := &genome.Band{Band: "q1", Chr: chr, StartPos: 60, EndPos: 100, Giemsa: "gpos50"}
+
+	var want []interface{}
+	for _, bands := range [][]*genome.Band{{in}, {in, out}} {
+		id, err := rings.NewIdeogram([]*genome.Chromosome{chr}, bands, win, 10, 20, rings.UniformGap(0))
+		c.Assert(err, check.Equals, nil)
+		got := drawActions(id)
+		if want == nil {
+			c.Assert(got, check.Not(check.HasLen), 0)
+			want = got
+			continue
+		}
+		c.Check(got, check.DeepEquals, want)
+	}
+}
+
+func (s *S) TestSpacedArcs(c *check.C) {
+	human := &fs{name: "human"}
+	mouse := &fs{name: "mouse"}
+	chr := []feat.Feature{
+		&fs{start: 0, end: 100, name: "hs1", location: human},
+		&fs{start: 0, end: 100, name: "hs2", location: human},
+		&fs{start: 0, end: 100, name: "mm1", location: mouse},
+		&fs{start: 0, end: 100, name: "mm2", location: mouse},
+	}
+	base := rings.Arc{0, rings.Complete}
+
+	for _, t := range []struct {
+		gap  rings.Gapper
+		want []rings.Angle
+	}{
+		{
+			gap:  rings.UniformGap(0.02),
+			want: []rings.Angle{0.01, 0.02, 0.02, 0.02, 0.01},
+		},
+		{
+			gap:  rings.Gaps{0, 0.01, 0.1, 0.01, 0},
+			want: []rings.Angle{0, 0.01, 0.1, 0.01, 0},
+		},
+		{
+			gap: rings.GapFunc(func(left, right feat.Feature) float64 {
+				switch {
+				case left == nil || right == nil:
+					return 0.025
+				case left.Location() != right.Location():
+					return 0.1
+				default:
+					return 0.01
+				}
+			}),
+			want: []rings.Angle{0.025, 0.01, 0.1, 0.01, 0.025},
+		},
+	} {
+		b, err := rings.NewSpacedBlocks(chr, base, 80, 100, t.gap)
+		c.Assert(err, check.Equals, nil)
+
+		var sum rings.Angle
+		for _, g := range t.want {
+			sum += g
+		}
+		theta := base.Theta
+		for i, f := range chr {
+			arc, err := b.ArcOf(f, nil)
+			c.Check(err, check.Equals, nil)
+			c.Check(angleEquals(arc.Theta-theta, t.want[i]*rings.Complete), check.Equals, true,
+				check.Commentf("unexpected gap %d: got:%v want:%v", i, (arc.Theta-theta)/rings.Complete, t.want[i]))
+			c.Check(angleEquals(arc.Phi, (1-sum)*rings.Complete/4), check.Equals, true)
+			theta = arc.Theta + arc.Phi
+		}
+		c.Check(angleEquals(base.Theta+base.Phi-theta, t.want[len(chr)]*rings.Complete), check.Equals, true)
+
+		_, err = rings.NewLabels(b, 110, rings.NameLabels(b.Set)...)
+		c.Check(err, check.Equals, nil)
+	}
+
+	// A Gaps must specify every gap.
+	c.Check(func() { rings.NewSpacedArcs(base, chr, rings.Gaps{0, 0.01, 0.1}) }, check.PanicMatches,
+		"rings: gaps length mismatch: 3 gaps for 4 features")
+	_, err := rings.NewSpacedBlocks(chr, base, 80, 100, rings.Gaps{0, 0.01, 0.1})
+	c.Check(err, check.ErrorMatches, "rings: gaps length mismatch: 3 gaps for 4 features")
+}
+
+func (s *S) TestPositionOf(c *check.C) {
+	chr := []feat.Feature{
+		&fs{start: 0, end: 1000, name: "chr1"},
+		&fs{start: 0, end: 1000, name: "chr2", orient: feat.Reverse},
+	}
+	base := rings.Arc{rings.Complete / 4, rings.Complete * rings.Clockwise}
+	arcs := rings.NewGappedArcs(base, chr, 0)
+	zoomed, err := rings.NewZoomedArcs(base, chr, 0, []rings.Zoom{{Feature: chr[0], Start: 0, End: 500, Scale: 3}})
+	c.Assert(err, check.Equals, nil)
+	windowed, err := rings.NewWindowedArcs(base, []feat.Feature{
+		&rings.Window{Loc: chr[1], From: 500, To: 1000, Orient: feat.Reverse},
+		&rings.Window{Loc: chr[0], From: 0, To: 500},
+	}, 0)
+	c.Assert(err, check.Equals, nil)
+	blocks, err := rings.NewBlocks(chr, zoomed, 80, 100)
+	c.Assert(err, check.Equals, nil)
+
+	for _, base := range []rings.PositionOfer{arcs, zoomed, windowed, blocks} {
+		for _, q := range []*fs{
+			{start: 100, end: 101, location: chr[0]},
+			{start: 250, end: 251, location: chr[0]},
+			{start: 600, end: 601, location: chr[0]},
+			{start: 100, end: 101, location: chr[1]},
+			{start: 700, end: 701, location: chr[1]},
+		} {
+			arc, err := base.ArcOf(q.Location(), q)
+			if err == rings.ErrOutsideWindows {
+				continue
+			}
+			c.Assert(err, check.Equals, nil)
+
+			// Query the centre of the base position.
+			f, pos, err := base.PositionOf(arc.Theta + arc.Phi/2)
+			c.Check(err, check.Equals, nil)
+			c.Check(f, check.Equals, q.Location(), check.Commentf("%T %v", base, q))
+			c.Check(pos, check.Equals, q.Start(), check.Commentf("%T %v", base, q))
+		}
+	}
+
+	_, _, err = windowed.PositionOf(rings.Complete / 2)
+	c.Check(err, check.Equals, nil)
+	gapped := rings.NewGappedArcs(base, chr, 0.1)
+	_, _, err = gapped.PositionOf(rings.Complete / 4)
+	c.Check(err, check.Not(check.Equals), nil)
+
+	// Angles on the boundary between abutting features map to the
+	// start of the following feature, whatever the map order.
+	abut := []feat.Feature{
+		&fs{start: 0, end: 1000, name: "chr1"},
+		&fs{start: 0, end: 1000, name: "chr2"},
+		&fs{start: 0, end: 1000, name: "chr3"},
+	}
+	arcs = rings.NewGappedArcs(base, abut, 0)
+	zoomed, err = rings.NewZoomedArcs(base, abut, 0, []rings.Zoom{{Feature: abut[1], Start: 0, End: 500, Scale: 3}})
+	c.Assert(err, check.Equals, nil)
+	for _, base := range []rings.PositionOfer{arcs, zoomed} {
+		for _, q := range []*fs{
+			{start: 0, end: 1, location: abut[1]},
+			{start: 0, end: 1, location: abut[2]},
+		} {
+			arc, err := base.ArcOf(q.Location(), q)
+			c.Assert(err, check.Equals, nil)
+			for i := 0; i < 10; i++ {
+				f, pos, err := base.PositionOf(arc.Theta)
+				c.Check(err, check.Equals, nil)
+				c.Check(f, check.Equals, q.Location(), check.Commentf("%T %v", base, q))
+				c.Check(pos, check.Equals, q.Start(), check.Commentf("%T %v", base, q))
+			}
+		}
+	}
+	segs := zoomed.Segments[abut[1]]
+	c.Assert(len(segs), check.Equals, 2)
+	f, pos, err := zoomed.PositionOf(segs[1].Theta)
+	c.Check(err, check.Equals, nil)
+	c.Check(f, check.Equals, abut[1])
+	c.Check(pos, check.Equals, segs[1].Start)
+}
+
+func (s *S) TestArcsOf(c *check.C) {
+	rand.Seed(1)
+	chr := randomFeatures(3, 100000, 1000000, false, plotter.DefaultLineStyle)
+	chr[1].(*fs).orient = feat.Reverse
+	base := rings.Arc{0, rings.Complete * rings.Clockwise}
+	zoomed, err := rings.NewZoomedArcs(base, chr, 0.01, []rings.Zoom{{Feature: chr[0], Scale: 2}})
+	c.Assert(err, check.Equals, nil)
+	blocks, err := rings.NewGappedBlocks(chr, base, 80, 100, 0.01)
+	c.Assert(err, check.Equals, nil)
+
+	var feats []feat.Feature
+	for i := 0; i < 100; i++ {
+		loc := chr[rand.Intn(len(chr))]
+		start := loc.Start() + rand.Intn(loc.Len())
+		feats = append(feats, &fs{start: start, end: start + rand.Intn(loc.End()-start), location: loc})
+	}
+
+	for _, a := range []rings.ArcsOfer{rings.NewGappedArcs(base, chr, 0.01), zoomed, blocks} {
+		arcs, err := a.ArcsOf(nil, feats)
+		c.Assert(err, check.Equals, nil)
+		c.Assert(len(arcs), check.Equals, len(feats))
+		for i, f := range feats {
+			want, err := a.ArcOf(f.Location(), f)
+			c.Check(err, check.Equals, nil)
+			c.Check(arcs[i], check.Equals, want)
+		}
+
+		_, err = a.ArcsOf(nil, append(feats, &fs{start: 10, end: 20, location: &fs{end: 100}}))
+		c.Check(err, check.Not(check.Equals), nil)
+	}
+}
+
+func (s *S) TestSpiral(c *check.C) {
+	c.Check(rings.Spiral(0, 0, 10, 5), check.Equals, rings.Rectangular(0, 10))
+	c.Check(rings.Spiral(2*rings.Complete, 0, 10, 5), check.Equals, rings.Rectangular(2*rings.Complete, 20))
+	c.Check(rings.Spiral(-rings.Complete/2, 0, 10, 5), check.Equals, rings.Rectangular(-rings.Complete/2, 12.5))
+
+	chr := []feat.Feature{
+		&fs{start: 0, end: 100, name: "A"},
+		&fs{start: 0, end: 100, name: "B"},
+		&fs{start: 0, end: 100, name: "C"},
+		&fs{start: 0, end: 100, name: "D"},
+	}
+	sp := rings.NewSpiralArcs(rings.Arc{0, 2 * rings.Complete}, chr, rings.UniformGap(0), 10)
+	for i, f := range chr {
+		arc, err := sp.ArcOf(f, nil)
+		c.Check(err, check.Equals, nil)
+		c.Check(arcEquals(arc, rings.Arc{rings.Angle(i) * rings.Complete / 2, rings.Complete / 2}), check.Equals, true)
+	}
+
+	proj := sp.Projection()
+	var pa vg.Path
+	pa.Move(proj.Point(rings.Complete, 50))
+	proj.Arc(&pa, vg.Point{}, 50, rings.Complete, rings.Complete/2)
+	c.Assert(len(pa) > 2, check.Equals, true)
+	for i, pc := range pa {
+		if i != 0 {
+			c.Check(pc.Type, check.Equals, vg.LineComp)
+		}
+		theta, r := rings.Polar(pc.Pos)
+		want := 60 + 10*float64(theta/rings.Complete)
+		c.Check(math.Abs(float64(r)-want) < 1e-9, check.Equals, true, check.Commentf("component %d", i))
+	}
+	c.Check(proj.Bounds(50), check.Equals, vg.Rectangle{Min: vg.Point{-70, -70}, Max: vg.Point{70, 70}})
+
+	blocks, err := rings.NewGappedBlocks(chr, sp, 50, 60, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(blocks.Projection(), check.Equals, proj)
+	polar, err := rings.NewGappedBlocks(chr, rings.Arc{0, rings.Complete}, 50, 60, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(polar.Projection().Point(rings.Complete/4, 50), check.Equals, rings.Rectangular(rings.Complete/4, 50))
+}
+
+func (s *S) TestOrder(c *check.C) {
+	chr := []*fs{
+		{start: 0, end: 100, name: "A"},
+		{start: 0, end: 100, name: "B"},
+		{start: 0, end: 100, name: "C"},
+		{start: 0, end: 100, name: "D"},
+	}
+	feats := []feat.Feature{chr[0], chr[1], chr[2], chr[3]}
+	link := func(a, b *fs, pos int) fp {
+		return fp{feats: [2]*fs{
+			{start: pos, end: pos + 10, location: a},
+			{start: pos, end: pos + 10, location: b},
+		}}
+	}
+	pairs := []rings.Pair{link(chr[0], chr[2], 20), link(chr[0], chr[2], 60), link(chr[1], chr[3], 20), link(chr[1], chr[3], 60)}
+
+	for _, obj := range []rings.Objective{rings.Crossings, rings.ChordLength} {
+		o, err := rings.Order(feats, pairs, obj, false)
+		c.Assert(err, check.Equals, nil)
+		c.Check(o.Reversed, check.DeepEquals, []bool{false, false, false, false})
+		pos := make(map[feat.Feature]int)
+		for i, f := range o.Features {
+			pos[f] = i
+		}
+		c.Assert(len(pos), check.Equals, len(feats))
+		for _, p := range [][2]int{{0, 2}, {1, 3}} {
+			d := pos[feats[p[0]]] - pos[feats[p[1]]]
+			c.Check(d == 1 || d == -1 || d == 3 || d == -3, check.Equals, true, check.Commentf("objective %d", obj))
+		}
+		_, err = rings.NewGappedArcs(rings.Arc{0, rings.Complete}, o.Features, 0.01).ArcOf(chr[2], nil)
+		c.Check(err, check.Equals, nil)
+	}
+
+	pairs = []rings.Pair{link(chr[0], chr[1], 0)}
+	o, err := rings.Order(feats[:2], pairs, rings.ChordLength, true)
+	c.Assert(err, check.Equals, nil)
+	c.Check(o.Reversed[0] != o.Reversed[1], check.Equals, true)
+	ws := o.Windows()
+	for i, w := range ws {
+		c.Check(w.Location(), check.Equals, o.Features[i])
+		c.Check(w.(feat.Orienter).Orientation() == feat.Reverse, check.Equals, o.Reversed[i])
+	}
+	arcs, err := rings.NewWindowedArcs(rings.Arc{0, rings.Complete}, ws, 0)
+	c.Assert(err, check.Equals, nil)
+	var mid [2]rings.Angle
+	for i, f := range pairs[0].Features() {
+		arc, err := arcs.ArcOf(f.Location(), f)
+		c.Assert(err, check.Equals, nil)
+		mid[i] = rings.Normalize(arc.Theta + arc.Phi/2)
+	}
+	d := math.Abs(float64(mid[0] - mid[1]))
+	c.Check(math.Min(d, float64(rings.Complete)-d) < float64(rings.Complete)/10, check.Equals, true)
+
+	_, err = rings.Order(feats[:2], []rings.Pair{link(chr[0], chr[2], 0)}, rings.Crossings, false)
+	c.Check(err, check.Not(check.Equals), nil)
+	_, err = rings.Order(append(feats, chr[0]), nil, rings.Crossings, false)
+	c.Check(err, check.Not(check.Equals), nil)
+}
+
+func (s *S) TestLinear(c *check.C) {
+	chr := []*fs{
+		{start: 0, end: 100, name: "A"},
+		{start: 0, end: 100, name: "B"},
+	}
+	lin := rings.NewLinearArcs(rings.Arc{0, 200}, []feat.Feature{chr[0], chr[1]}, rings.UniformGap(0), 200)
+	arc, err := lin.ArcOf(chr[1], nil)
+	c.Assert(err, check.Equals, nil)
+	c.Check(arc, check.Equals, rings.Arc{100, 100})
+
+	b, err := rings.NewGappedBlocks([]feat.Feature{chr[0], chr[1]}, lin, 10, 20, 0)
+	c.Assert(err, check.Equals, nil)
+	b.Color = color.Black
+	c.Check(b.Projection().Bounds(b.Outer), check.Equals, vg.Rectangle{Max: vg.Point{200, 20}})
+
+	l, err := rings.NewLinks([]rings.Pair{fp{feats: [2]*fs{
+		{start: 10, end: 20, location: chr[0], style: plotter.DefaultLineStyle},
+		{start: 10, end: 20, location: chr[1], style: plotter.DefaultLineStyle},
+	}, sty: plotter.DefaultLineStyle}}, [2]rings.ArcOfer{lin, lin}, [2]vg.Length{10, 10})
+	c.Assert(err, check.Equals, nil)
+	l.Bezier = &rings.Bezier{Segments: 2, Radius: rings.LengthDist{Length: 50}}
+
+	tc := &canvas{dpi: defaultDPI}
+	ca := draw.NewCanvas(tc, 300, 300)
+	b.DrawAt(ca, vg.Point{10, 10})
+	l.DrawAt(ca, vg.Point{10, 10})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Black},
+		fill{path: vg.Path{
+			{Type: vg.MoveComp, Pos: vg.Point{X: 10, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 10, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 110, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 110, Y: 30}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 10, Y: 30}},
+			{Type: vg.CloseComp},
+		}},
+		setColor{col: color.Black},
+		fill{path: vg.Path{
+			{Type: vg.MoveComp, Pos: vg.Point{X: 110, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 110, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 210, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 210, Y: 30}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 110, Y: 30}},
+			{Type: vg.CloseComp},
+		}},
+		setColor{col: color.RGBA{A: 0xfe}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: vg.Path{
+			{Type: vg.MoveComp, Pos: vg.Point{X: 20, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 70, Y: 40}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 120.00000000000001, Y: 20}},
+		}},
+	})
+
+	// Renderers layered on a linear base inherit its projection.
+	proj := lin.Projection()
+	feats := []feat.Feature{chr[0], chr[1]}
+	sc, err := rings.NewScores([]rings.Scorer{&fs{start: 0, end: 10, location: chr[0], scores: []float64{1}}}, lin, 10, 20, &rings.Trace{})
+	c.Assert(err, check.Equals, nil)
+	la, err := rings.NewLabels(lin, 30, rings.NameLabels(feats)...)
+	c.Assert(err, check.Equals, nil)
+	ge, err := rings.NewGenes(nil, lin, 10, 20)
+	c.Assert(err, check.Equals, nil)
+	sa, err := rings.NewSail(feats, lin, 10)
+	c.Assert(err, check.Equals, nil)
+	sl, err := rings.NewScale(feats, lin, 10)
+	c.Assert(err, check.Equals, nil)
+	for _, r := range []rings.Projector{b, sc, la, ge, sa, sl} {
+		c.Check(r.Projection(), check.Equals, proj, check.Commentf("%T", r))
+	}
+
+	h := rings.NewHighlight(color.Black, rings.Arc{50, 100}, 10, 20)
+	h.Projector = lin
+	tc = &canvas{dpi: defaultDPI}
+	h.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Black},
+		fill{path: vg.Path{
+			{Type: vg.MoveComp, Pos: vg.Point{X: 50, Y: 10}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 50, Y: 10}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 150, Y: 10}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 150, Y: 20}},
+			{Type: vg.LineComp, Pos: vg.Point{X: 50, Y: 20}},
+			{Type: vg.CloseComp},
+		}},
+	})
+}
+
+func (s *S) TestBlocksTiles(c *check.C) {
+	chr, lin := linearChr()
+	var feats []feat.Feature
+	for _, r := range [][2]int{{0, 30}, {10, 40}, {20, 50}, {35, 60}, {70, 80}} {
+		feats = append(feats, &fs{start: r[0], end: r[1], location: chr})
+	}
+
+	for i, t := range []struct {
+		tiles rings.Tiles
+		outer vg.Length
+		radii [][2]vg.Length
+	}{
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1},
+			outer: 40,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {22, 27}, {10, 15}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, Margin: 10},
+			outer: 40,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {22, 27}, {28, 33}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, MaxLayers: 2},
+			outer: 40,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {10, 15}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, MaxLayers: 2, Overflow: rings.OverflowCollapse},
+			outer: 40,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {16, 21}, {10, 15}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, Direction: rings.TileInward},
+			outer: 40,
+			radii: [][2]vg.Length{{35, 40}, {29, 34}, {23, 28}, {35, 40}, {35, 40}},
+		},
+
+		// Layers are bounded by the outer radius.
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, Margin: 10},
+			outer: 27,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {22, 27}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, Overflow: rings.OverflowCollapse},
+			outer: 21,
+			radii: [][2]vg.Length{{10, 15}, {16, 21}, {16, 21}, {10, 15}, {10, 15}},
+		},
+		{
+			tiles: rings.Tiles{Thickness: 5, Padding: 1, Direction: rings.TileInward},
+			outer: 27,
+			radii: [][2]vg.Length{{22, 27}, {16, 21}, {10, 15}, {22, 27}, {22, 27}},
+		},
+	} {
+		b, err := rings.NewBlocks(feats, lin, 10, t.outer)
+		c.Assert(err, check.Equals, nil)
+		b.Color = color.Black
+		tiles := t.tiles
+		b.Tiles = &tiles
+
+		tc := &canvas{dpi: defaultDPI}
+		b.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var radii [][2]vg.Length
+		for _, a := range tc.actions {
+			if f, ok := a.(fill); ok {
+				radii = append(radii, [2]vg.Length{f.path[0].Pos.Y, f.path[3].Pos.Y})
+			}
+		}
+		c.Check(radii, check.DeepEquals, t.radii, check.Commentf("Test %d", i))
+
+		p, err := plot.New()
+		c.Assert(err, check.Equals, nil)
+		c.Check(b.GlyphBoxes(p)[0].Rectangle.Max.Y, check.Equals, t.outer, check.Commentf("Test %d", i))
+	}
+
+	// Blocks crossing angle zero overlap the blocks at the start of the circle.
+	circ := rings.NewGappedArcs(rings.Arc{rings.Complete / 2, rings.Complete}, []feat.Feature{chr}, 0)
+	feats = []feat.Feature{
+		&fs{start: 40, end: 60, location: chr},
+		&fs{start: 55, end: 65, location: chr},
+		&fs{start: 70, end: 80, location: chr},
+	}
+	b, err := rings.NewBlocks(feats, circ, 10, 40)
+	c.Assert(err, check.Equals, nil)
+	b.Color = color.Black
+	b.Tiles = &rings.Tiles{Thickness: 5, Padding: 1}
+	tc := &canvas{dpi: defaultDPI}
+	b.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	var radii []vg.Length
+	for _, a := range tc.actions {
+		if f, ok := a.(fill); ok {
+			radii = append(radii, f.path[1].Radius)
+		}
+	}
+	c.Check(radii, check.DeepEquals, []vg.Length{16, 10, 10})
+}
+
+func (s *S) TestBlocksArrows(c *check.C) {
+	chr, lin := linearChr()
+	feats := []feat.Feature{
+		&fs{start: 10, end: 50, location: chr, orient: feat.Forward},
+		&fs{start: 60, end: 90, location: chr, orient: feat.Reverse},
+		&fs{start: 92, end: 97, location: chr, orient: feat.Forward},
+		&fs{start: 0, end: 5, location: chr},
+	}
+	sectors := []vg.Path{
+		{mv(92, 10), pt(92, 10), pt(97, 10), pt(97, 20), pt(92, 20), cl},
+		{mv(0, 10), pt(0, 10), pt(5, 10), pt(5, 20), pt(0, 20), cl},
+	}
+
+	for _, t := range []struct {
+		shape rings.BlockShape
+		paths []vg.Path
+	}{
+		{
+			shape: rings.Arrow,
+			paths: []vg.Path{
+				{mv(10, 10), pt(10, 10), pt(40, 10), pt(50, 15), pt(40, 20), pt(40, 20), pt(10, 20), cl},
+				{mv(90, 10), pt(90, 10), pt(70, 10), pt(60, 15), pt(70, 20), pt(70, 20), pt(90, 20), cl},
+			},
+		},
+		{
+			shape: rings.Chevron,
+			paths: []vg.Path{
+				{mv(10, 10), pt(10, 10), pt(40, 10), pt(50, 15), pt(40, 20), pt(40, 20), pt(10, 20), pt(20, 15), cl},
+				{mv(90, 10), pt(90, 10), pt(70, 10), pt(60, 15), pt(70, 20), pt(70, 20), pt(90, 20), pt(80, 15), cl},
+			},
+		},
+	} {
+		b, err := rings.NewBlocks(feats, lin, 10, 20)
+		c.Assert(err, check.Equals, nil)
+		b.Color = color.Black
+		b.Shape = t.shape
+		b.Head = 10
+
+		tc := &canvas{dpi: defaultDPI}
+		b.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var paths []vg.Path
+		for _, a := range tc.actions {
+			if f, ok := a.(fill); ok {
+				paths = append(paths, f.path)
+			}
+		}
+		c.Check(paths, check.DeepEquals, append(t.paths, sectors...), check.Commentf("Shape %d", t.shape))
+	}
+}
+
+func (s *S) TestGenes(c *check.C) {
+	chr, lin := linearChr()
+
+	coding := &gene.CodingTranscript{ID: "coding", Loc: chr, Offset: 10, Orient: feat.Forward, CDSstart: 10, CDSend: 50}
+	c.Assert(coding.SetExons(gene.Exon{Transcript: coding, Offset: 0, Length: 20}, gene.Exon{Transcript: coding, Offset: 30, Length: 30}), check.Equals, nil)
+	nc := &gene.NonCodingTranscript{ID: "non-coding", Loc: chr, Offset: 80, Orient: feat.Reverse}
+	c.Assert(nc.SetExons(gene.Exon{Transcript: nc, Offset: 0, Length: 5}, gene.Exon{Transcript: nc, Offset: 15, Length: 5}), check.Equals, nil)
+
+	_, err := rings.NewGenes([]feat.Feature{chr}, lin, 10, 30)
+	c.Check(err, check.Not(check.Equals), nil)
+	g, err := rings.NewGenes([]feat.Feature{coding, nc}, lin, 10, 30)
+	c.Assert(err, check.Equals, nil)
+	g.Color = color.Black
+	g.IntronStyle = plotter.DefaultLineStyle
+	g.ChevronSpacing = 5
+
+	block := func(x0, x1, y0, y1 vg.Length) interface{} {
+		return fill{path: vg.Path{mv(x0, y0), pt(x0, y0), pt(x1, y0), pt(x1, y1), pt(x0, y1), cl}}
+	}
+
+	tc := &canvas{dpi: defaultDPI}
+	g.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: vg.Path{mv(30, 20), pt(30, 20), pt(40, 20)}},
+		stroke{path: vg.Path{mv(31.25, 25), pt(33.75, 20), pt(31.25, 15)}},
+		stroke{path: vg.Path{mv(36.25, 25), pt(38.75, 20), pt(36.25, 15)}},
+		setColor{col: color.Black},
+		block(10, 20, 15, 25),
+		setColor{col: color.Black},
+		block(20, 30, 10, 30),
+		setColor{col: color.Black},
+		block(40, 60, 10, 30),
+		setColor{col: color.Black},
+		block(60, 70, 15, 25),
+
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: vg.Path{mv(85, 20), pt(85, 20), pt(95, 20)}},
+		stroke{path: vg.Path{mv(88.75, 25), pt(86.25, 20), pt(88.75, 15)}},
+		stroke{path: vg.Path{mv(93.75, 25), pt(91.25, 20), pt(93.75, 15)}},
+		setColor{col: color.Black},
+		block(80, 85, 10, 30),
+		setColor{col: color.Black},
+		block(95, 100, 10, 30),
+	})
+
+	// Exons follow non-uniform and windowed mappings of their location.
+	zoomed, err := rings.NewZoomedArcs(rings.Arc{0, rings.Complete}, []feat.Feature{chr}, 0,
+		[]rings.Zoom{{Feature: chr, Start: 0, End: 50, Scale: 3}})
+	c.Assert(err, check.Equals, nil)
+	windowed, err := rings.NewWindowedArcs(rings.Arc{0, rings.Complete}, []feat.Feature{
+		&rings.Window{Loc: chr, From: 0, To: 50},
+	}, 0)
+	c.Assert(err, check.Equals, nil)
+	for _, t := range []struct {
+		base  rings.ArcOfer
+		parts [][2]int
+	}{
+		{base: zoomed, parts: [][2]int{{10, 20}, {20, 30}, {40, 60}, {60, 70}, {80, 85}, {95, 100}}},
+		{base: windowed, parts: [][2]int{{10, 20}, {20, 30}, {40, 50}}},
+	} {
+		g.Base = t.base
+		tc = &canvas{dpi: defaultDPI}
+		g.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []rings.Arc
+		for _, a := range tc.actions {
+			if f, ok := a.(fill); ok {
+				got = append(got, rings.Arc{rings.Angle(f.path[1].Start), rings.Angle(f.path[1].Angle)})
+			}
+		}
+		c.Assert(len(got), check.Equals, len(t.parts), check.Commentf("%T", t.base))
+		for i, p := range t.parts {
+			want, err := t.base.ArcOf(chr, &fs{start: p[0], end: p[1], location: chr})
+			c.Assert(err, check.Equals, nil)
+			c.Check(arcEquals(got[i], want), check.Equals, true, check.Commentf("%T part %d: got:%v want:%v", t.base, i, got[i], want))
+		}
+	}
+}
+
+func (s *S) TestIdeogram(c *check.C) {
+	chrs := []*genome.Chromosome{
+		{Chr: "chr1", Length: 50},
+		{Chr: "chr2", Length: 50},
+	}
+	bands := []*genome.Band{
+		{Band: "p12", Chr: chrs[0], StartPos: 0, EndPos: 20, Giemsa: "gneg"},
+		{Band: "p11", Chr: chrs[0], StartPos: 20, EndPos: 25, Giemsa: "acen"},
+		{Band: "q11", Chr: chrs[0], StartPos: 25, EndPos: 30, Giemsa: "acen"},
+		{Band: "q12", Chr: chrs[0], StartPos: 30, EndPos: 50, Giemsa: "gpos50"},
+		{Band: "pA", Chr: chrs[1], StartPos: 0, EndPos: 5, Giemsa: "gneg"},
+		{Band: "qA", Chr: chrs[1], StartPos: 5, EndPos: 35, Giemsa: "gvar"},
+		{Band: "qB", Chr: chrs[1], StartPos: 35, EndPos: 50, Giemsa: "stalk"},
+	}
+	fs := []feat.Feature{chrs[0], chrs[1]}
+	lin := rings.NewLinearArcs(rings.Arc{0, 100}, fs, rings.UniformGap(0), 100)
+
+	_, err := rings.NewIdeogram(chrs, []*genome.Band{{Band: "p1", Chr: chrs[0], StartPos: 40, EndPos: 60}}, lin, 10, 30, rings.UniformGap(0))
+	c.Check(err, check.Not(check.Equals), nil)
+	_, err = rings.NewIdeogram(chrs, bands, rings.Arc{0, rings.Complete}, 10, 30, rings.Gaps{0})
+	c.Check(err, check.ErrorMatches, "rings: gaps length mismatch: 1 gaps for 2 features")
+	id, err := rings.NewIdeogram(chrs, bands, lin, 10, 30, rings.UniformGap(0))
+	c.Assert(err, check.Equals, nil)
+	id.HatchSpacing = 10
+	id.HatchStyle = plotter.DefaultLineStyle
+	id.LineStyle = plotter.DefaultLineStyle
+
+	block := func(x0, x1, y0, y1 vg.Length) vg.Path {
+		return vg.Path{mv(x0, y0), pt(x0, y0), pt(x1, y0), pt(x1, y1), pt(x0, y1), cl}
+	}
+	line := func(x vg.Length) interface{} { return stroke{path: vg.Path{mv(x, 10), pt(x, 30)}} }
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	tc := &canvas{dpi: defaultDPI}
+	id.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Gray{Y: 0xff}},
+		fill{path: block(0, 20, 10, 30)},
+		setColor{col: red},
+		fill{path: vg.Path{mv(20, 10), pt(20, 30), pt(25, 20), cl}},
+		setColor{col: red},
+		fill{path: vg.Path{mv(30, 10), pt(30, 30), pt(25, 20), cl}},
+		setColor{col: color.Gray{Y: 0x7f}},
+		fill{path: block(30, 50, 10, 30)},
+		setColor{col: color.Gray{Y: 0xff}},
+		fill{path: block(50, 55.00000000000001, 10, 30)},
+		setColor{col: color.RGBA{R: 0xbc, G: 0xbd, B: 0xdc, A: 0xff}},
+		fill{path: block(55.00000000000001, 85, 10, 30)},
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		line(62.5),
+		line(70),
+		line(77.5),
+		setColor{col: color.Gray{Y: 0x0}},
+		fill{path: block(85, 100, 15, 25)},
+
+		// Inferred centromere of the chromosome without acen bands.
+		setColor{col: red},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		line(55.00000000000001),
+
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: block(0, 50, 10, 30)},
+		stroke{path: block(50, 100, 10, 30)},
+	})
+
+	// The palette of an Ideogram is independent of the Giemsa palette.
+	gneg := rings.Giemsa["gneg"]
+	id.Palette["gneg"] = red
+	c.Check(rings.Giemsa["gneg"], check.Equals, gneg)
+
+	id.Centromere = rings.RoundedCentromere
+	tc = &canvas{dpi: defaultDPI}
+	id.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	p := tc.actions[3].(fill).path
+	c.Check(len(p), check.Equals, 34)
+	c.Check(p[0], check.DeepEquals, mv(20, 10))
+	c.Check(p[1], check.DeepEquals, pt(20, 30))
+	c.Check(p[17], check.DeepEquals, pt(25, 20))
+
+	// Acrocentric chromosomes without acen bands taper at the p-terminal end.
+	acro := []*genome.Chromosome{{Chr: "chr1", Length: 100}}
+	id, err = rings.NewIdeogram(acro, []*genome.Band{
+		{Band: "qA", Chr: acro[0], StartPos: 0, EndPos: 20, Giemsa: "gpos100"},
+		{Band: "qB", Chr: acro[0], StartPos: 20, EndPos: 100, Giemsa: "gneg"},
+	}, rings.NewLinearArcs(rings.Arc{0, 100}, []feat.Feature{acro[0]}, rings.UniformGap(0), 100), 10, 30, rings.UniformGap(0))
+	c.Assert(err, check.Equals, nil)
+	tc = &canvas{dpi: defaultDPI}
+	id.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Gray{Y: 0x0}},
+		fill{path: vg.Path{mv(20, 10), pt(20, 30), pt(0, 20), cl}},
+		setColor{col: color.Gray{Y: 0xff}},
+		fill{path: block(20, 100, 10, 30)},
+	})
+	id.Centromere = rings.RoundedCentromere
+	tc = &canvas{dpi: defaultDPI}
+	id.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	p = tc.actions[1].(fill).path
+	c.Check(len(p), check.Equals, 34)
+	c.Check(p[0], check.DeepEquals, mv(20, 10))
+	c.Check(p[17], check.DeepEquals, pt(0, 20))
+}
+
+func (s *S) TestSmoothers(c *check.C) {
+	nan := math.NaN()
+	g := math.Exp(-0.5)
+	for i, t := range []struct {
+		smoother rings.Smoother
+		pos      []float64
+		values   []float64
+		want     []float64
+	}{
+		{
+			smoother: rings.RollingMean(20),
+			pos:      []float64{0, 10, 20, 30, 40},
+			values:   []float64{1, 5, nan, 3, 7},
+			want:     []float64{3, 3, nan, 5, 5},
+		},
+		{
+			smoother: rings.RollingMedian(40),
+			pos:      []float64{0, 10, 20, 30, 40},
+			values:   []float64{1, 5, nan, 3, 7},
+			want:     []float64{3, 3, nan, 5, 5},
+		},
+		{
+			smoother: rings.RollingMean(0),
+			pos:      []float64{0, 10, 20},
+			values:   []float64{1, nan, 3},
+			want:     []float64{1, nan, 3},
+		},
+		{
+			smoother: rings.Gaussian(60),
+			pos:      []float64{0, 10, 20},
+			values:   []float64{0, 1, 0},
+			want:     []float64{g / (1 + g + math.Exp(-2)), 1 / (1 + 2*g), g / (1 + g + math.Exp(-2))},
+		},
+		{
+			// Local linear regression reproduces linear data.
+			smoother: rings.Loess(50),
+			pos:      []float64{0, 5, 20, 25, 40, 60},
+			values:   []float64{1, 11, 41, 51, 81, 121},
+			want:     []float64{1, 11, 41, 51, 81, 121},
+		},
+	} {
+		got := make([]float64, len(t.values))
+		t.smoother.Smooth(got, t.pos, t.values)
+		for k, v := range t.want {
+			if math.IsNaN(v) {
+				c.Check(math.IsNaN(got[k]), check.Equals, true, check.Commentf("Test %d value %d", i, k))
+				continue
+			}
+			c.Check(math.Abs(got[k]-v) < 1e-9, check.Equals, true, check.Commentf("Test %d value %d: got:%v want:%v", i, k, got[k], v))
+		}
+	}
+}
+
+func (s *S) TestTraceSmoothing(c *check.C) {
+	chr := []*fs{
+		{start: 0, end: 100, name: "A"},
+		{start: 0, end: 100, name: "B"},
+	}
+	lin := rings.NewLinearArcs(rings.Arc{0, 200}, []feat.Feature{chr[0], chr[1]}, rings.UniformGap(0), 200)
+	vals := []float64{0, 0, 0, 0, 3, 0, 0, 0, 0, 0}
+	var set []rings.Scorer
+	for k, f := range chr {
+		set = append(set, makeScorers(f, 10, 1, func(i, _ int) float64 { return vals[i] + float64(10*k) })...)
+	}
+
+	gray := draw.LineStyle{Color: color.Gray{0x80}, Width: 1}
+	for i, t := range []struct {
+		raw  []draw.LineStyle
+		want []vg.Length
+	}{
+		{
+			// Smoothing does not cross the boundary between A and B.
+			want: []vg.Length{
+				10, 10, 10, 11, 11, 11, 10, 10, 10, 10,
+				20, 20, 20, 21, 21, 21, 20, 20, 20, 20,
+			},
+		},
+		{
+			raw: []draw.LineStyle{gray},
+			want: []vg.Length{
+				10, 10, 10, 10, 13, 10, 10, 10, 10, 10,
+				20, 20, 20, 20, 23, 20, 20, 20, 20, 20,
+
+				10, 10, 10, 11, 11, 11, 10, 10, 10, 10,
+				20, 20, 20, 21, 21, 21, 20, 20, 20, 20,
+			},
+		},
+	} {
+		tr := &rings.Trace{
+			LineStyles: []draw.LineStyle{plotter.DefaultLineStyle},
+			Smoother:   rings.RollingMean(30),
+			Raw:        t.raw,
+			Min:        0,
+			Max:        20,
+		}
+		sc, err := rings.NewScores(set, lin, 10, 30, tr)
+		c.Assert(err, check.Equals, nil)
+
+		tc := &canvas{dpi: defaultDPI}
+		sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []vg.Length
+		for _, act := range tc.actions {
+			if s, ok := act.(stroke); ok {
+				got = append(got, s.path[0].Pos.Y)
+			}
+		}
+		c.Check(got, check.DeepEquals, t.want, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestHistogram(c *check.C) {
+	chr, lin := linearChr()
+	vals := []float64{2, -1, math.NaN(), 3}
+	set := makeScorers(chr, 4, 1, func(i, _ int) float64 { return vals[i] })
+
+	bar := func(x0, x1, y vg.Length) vg.Path {
+		return vg.Path{mv(x0, 20), pt(x0, y), pt(x0, y), pt(x1, y), pt(x1, 20), pt(x1, 20), pt(x0, 20), cl}
+	}
+
+	for i, t := range []struct {
+		join  bool
+		paths []vg.Path
+	}{
+		{
+			join:  false,
+			paths: []vg.Path{bar(0, 25, 30), bar(25, 50, 15), bar(75, 100, 35)},
+		},
+		{
+			join: true,
+			paths: []vg.Path{
+				{mv(0, 20), pt(0, 30), pt(0, 30), pt(25, 30), pt(25, 15), pt(25, 15), pt(50, 15), pt(50, 20), pt(50, 20), pt(0, 20), cl},
+				bar(75, 100, 35),
+			},
+		},
+	} {
+		h := &rings.Histogram{
+			Colors:     []color.Color{color.Black},
+			LineStyles: []draw.LineStyle{plotter.DefaultLineStyle},
+			Join:       t.join,
+			Min:        -2,
+			Max:        4,
+		}
+		sc, err := rings.NewScores(set, lin, 10, 40, h)
+		c.Assert(err, check.Equals, nil)
+
+		var want []interface{}
+		for _, p := range t.paths {
+			want = append(want,
+				setColor{col: color.Black},
+				fill{path: p},
+				setColor{col: color.Gray16{Y: 0x0}},
+				setWidth{w: 1},
+				setLineDash{dashes: []vg.Length(nil), offsets: 0},
+				stroke{path: p},
+			)
+		}
+
+		tc := &canvas{dpi: defaultDPI}
+		sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		c.Check(tc.actions, check.DeepEquals, want, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestScoresBaseline(c *check.C) {
+	chr, lin := linearChr()
+	vals := []float64{2, -1, math.NaN(), 3}
+	set := makeScorers(chr, 4, 1, func(i, _ int) float64 { return vals[i] })
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	gray := color.Gray{0x80}
+	h := &rings.Histogram{
+		Colors: []color.Color{color.Black},
+		Below:  []color.Color{red},
+		Inward: true,
+		Min:    -2,
+		Max:    4,
+		Axis: &rings.Axis{
+			Grid:     draw.LineStyle{Color: gray, Width: 1},
+			Baseline: draw.LineStyle{Color: red, Width: 2},
+			Tick: rings.TickConfig{
+				Marker: plot.ConstantTicks([]plot.Tick{{Value: -2, Label: "-2"}, {Value: 0, Label: "0"}, {Value: 2, Label: "2"}, {Value: 4, Label: "4"}}),
+			},
+		},
+	}
+	sc, err := rings.NewScores(set, lin, 10, 40, h)
+	c.Assert(err, check.Equals, nil)
+
+	line := func(y vg.Length) interface{} { return stroke{path: vg.Path{mv(0, y), pt(0, y), pt(100, y)}} }
+	// Scores increase inward, so the baseline at 0 is at radius 30.
+	bar := func(x0, x1, y vg.Length) interface{} {
+		return fill{path: vg.Path{mv(x0, 30), pt(x0, y), pt(x0, y), pt(x1, y), pt(x1, 30), pt(x1, 30), pt(x0, 30), cl}}
+	}
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		// Grid lines, excluding the line at the baseline.
+		setColor{col: gray},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		line(40),
+		line(20),
+		line(10),
+
+		// Baseline.
+		setColor{col: red},
+		setWidth{w: 2},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		line(30),
+
+		setColor{col: color.Black},
+		bar(0, 25, 20),
+		setColor{col: red},
+		bar(25, 50, 35),
+		setColor{col: color.Black},
+		bar(75, 100, 15),
+	})
+}
+
+func (s *S) TestScatter(c *check.C) {
+	chr, lin := linearChr()
+	vals := []float64{2, -1, math.NaN(), 3}
+	set := makeScorers(chr, 4, 1, func(i, _ int) float64 { return vals[i] })
+	set[1] = glyphScorer{set[1], draw.GlyphStyle{Color: color.White, Radius: 2, Shape: draw.BoxGlyph{}}}
+
+	sp := &rings.Scatter{
+		GlyphStyles: []draw.GlyphStyle{{Color: color.Black, Radius: 1, Shape: draw.BoxGlyph{}}},
+		Min:         -1,
+		Max:         2,
+	}
+	sc, err := rings.NewScores(set, lin, 10, 40, sp)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Assert(len(tc.actions), check.Equals, 4)
+	for i, want := range []struct {
+		col color.Color
+		pt  vg.Point
+	}{
+		{col: color.Gray16{Y: 0x0}, pt: vg.Point{X: 12.5, Y: 40}},
+		{col: color.Gray16{Y: 0xffff}, pt: vg.Point{X: 37.5, Y: 10}},
+	} {
+		c.Check(tc.actions[2*i], check.DeepEquals, setColor{col: want.col})
+		p := tc.actions[2*i+1].(fill).path
+		mid := p[0].Pos.Add(p[2].Pos).Scale(0.5)
+		c.Check(math.Abs(float64(mid.X-want.pt.X)) < 1e-10, check.Equals, true, check.Commentf("glyph %d x: %v", i, mid.X))
+		c.Check(math.Abs(float64(mid.Y-want.pt.Y)) < 1e-10, check.Equals, true, check.Commentf("glyph %d y: %v", i, mid.Y))
+	}
+}
+
+// glyphScorer is a Scorer with a specified glyph style.
+type glyphScorer struct {
+	rings.Scorer
+	sty draw.GlyphStyle
+}
+
+func (g glyphScorer) GlyphStyle(int) draw.GlyphStyle { return g.sty }
+
+func (s *S) TestArea(c *check.C) {
+	chr := &fs{start: 0, end: 75, name: "chr"}
+	lin := rings.NewLinearArcs(rings.Arc{0, 75}, []feat.Feature{chr}, rings.UniformGap(0), 75)
+	vals := [][]float64{{1, 1}, {2, -1}, {-1, 2}}
+	set := makeScorers(chr, 3, 2, func(i, j int) float64 { return vals[i][j] })
+
+	a := &rings.Area{
+		Above:      []color.Color{color.Black, color.White},
+		Below:      []color.Color{color.White, color.Black},
+		LineStyles: []draw.LineStyle{plotter.DefaultLineStyle},
+		Stack:      true,
+		Join:       true,
+		Min:        -2,
+		Max:        4,
+	}
+	sc, err := rings.NewScores(set, lin, 10, 40, a)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+
+	// path returns a path through the points in xy, closed if cl is true.
+	path := func(cl bool, xy ...vg.Length) vg.Path {
+		p := vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: xy[0], Y: xy[1]}}}
+		for i := 2; i < len(xy); i += 2 {
+			p = append(p, vg.PathComp{Type: vg.LineComp, Pos: vg.Point{X: xy[i], Y: xy[i+1]}})
+		}
+		if cl {
+			p = append(p, vg.PathComp{Type: vg.CloseComp})
+		}
+		return p
+	}
+	// span returns a closed path for a single region from x0 to x1 between lo and hi.
+	span := func(x0, x1, lo, hi vg.Length) vg.Path {
+		return path(true, x0, lo, x0, hi, x0, hi, x1, hi, x1, lo, x1, lo, x0, lo)
+	}
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		// First series regions above and below the baseline, and its trace.
+		setColor{col: color.Gray16{Y: 0x0}},
+		fill{path: path(true, 0, 20, 0, 25, 0, 25, 25, 25, 25, 30, 25, 30, 50, 30, 50, 20, 50, 20, 25, 20, 25, 20, 25, 20, 0, 20)},
+		setColor{col: color.Gray16{Y: 0xffff}},
+		fill{path: span(50, 75, 20, 15)},
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: path(false, 0, 25, 0, 25, 25, 25, 25, 30, 25, 30, 50, 30, 50, 15, 50, 15, 75, 15)},
+
+		// Second series stacked on the first.
+		setColor{col: color.Gray16{Y: 0xffff}},
+		fill{path: span(0, 25, 25, 30)},
+		setColor{col: color.Gray16{Y: 0x0}},
+		fill{path: span(25, 50, 30, 25)},
+		setColor{col: color.Gray16{Y: 0xffff}},
+		fill{path: span(50, 75, 15, 25)},
+	})
+}
+
+func (s *S) TestAreaStackBaseline(c *check.C) {
+	chr, lin := linearChr()
+	vals := [][]float64{{2, 1}, {math.NaN(), 3}}
+	set := makeScorers(chr, 2, 2, func(i, j int) float64 { return vals[i][j] })
+
+	a := &rings.Area{
+		Above:    []color.Color{color.Black, color.White},
+		Baseline: 1,
+		Stack:    true,
+		Min:      0,
+		Max:      4,
+	}
+	sc, err := rings.NewScores(set, lin, 0, 40, a)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+
+	// span returns a closed path for a single region from x0 to x1 between lo and hi.
+	span := func(x0, x1, lo, hi vg.Length) vg.Path {
+		return vg.Path{mv(x0, lo), pt(x0, hi), pt(x0, hi), pt(x1, hi), pt(x1, lo), pt(x1, lo), pt(x0, lo), cl}
+	}
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		// The first series extends from the baseline.
+		setColor{col: color.Gray16{Y: 0x0}},
+		fill{path: span(0, 50, 10, 20)},
+
+		// The second series is stacked on the first series, or
+		// extends from the baseline where the first series is NaN.
+		setColor{col: color.Gray16{Y: 0xffff}},
+		fill{path: span(0, 50, 20, 30)},
+		setColor{col: color.Gray16{Y: 0xffff}},
+		fill{path: span(50, 100, 10, 30)},
+	})
+}
+
+func (s *S) TestBand(c *check.C) {
+	chr, lin := linearChr()
+	vals := [][]float64{{2, 1, 3}, {3, 2, 4}, {math.NaN(), 1, 2}, {1, 0, 2}}
+	set := makeScorers(chr, 4, 3, func(i, j int) float64 { return vals[i][j] })
+
+	translucent := color.NRGBA{B: 0xff, A: 0x40}
+	b := &rings.Band{
+		Middle:    0,
+		Lower:     1,
+		Upper:     2,
+		Fill:      translucent,
+		LineStyle: plotter.DefaultLineStyle,
+		Join:      true,
+		Min:       0,
+		Max:       4,
+	}
+	sc, err := rings.NewScores(set, lin, 10, 50, b)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: translucent},
+		fill{path: vg.Path{
+			mv(0, 20), pt(0, 40), pt(0, 40), pt(25, 40), pt(25, 50), pt(25, 50), pt(50, 50),
+			pt(50, 30), pt(50, 30), pt(25, 30), pt(25, 20), pt(25, 20), pt(0, 20), cl,
+		}},
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: vg.Path{mv(0, 30), pt(0, 30), pt(25, 30), pt(25, 40), pt(25, 40), pt(50, 40)}},
+
+		// The NaN center value breaks the band.
+		setColor{col: translucent},
+		fill{path: vg.Path{mv(75, 10), pt(75, 30), pt(75, 30), pt(100, 30), pt(100, 10), pt(100, 10), pt(75, 10), cl}},
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		stroke{path: vg.Path{mv(75, 20), pt(75, 20), pt(100, 20)}},
+	})
+}
+
+func (s *S) TestTransforms(c *check.C) {
+	for _, t := range []struct {
+		t    rings.Transform
+		in   []float64
+		want []float64
+	}{
+		{t: rings.Log(0), in: []float64{-5, 0, 1, 100}, want: []float64{0, 0, 0, 2}},
+		{t: rings.Log(0.1), in: []float64{-5, 0, 0.1, 10}, want: []float64{-1, -1, -1, 1}},
+		{t: rings.NegLog(1e-10), in: []float64{-1, 0, 1e-12, 0.01, 1}, want: []float64{10, 10, 10, 2, 0}},
+		{t: rings.Sqrt{}, in: []float64{-4, 0, 4}, want: []float64{-2, 0, 2}},
+		{t: rings.Symlog(0), in: []float64{-9, 0, 9}, want: []float64{-1, 0, 1}},
+		{t: rings.Symlog(2), in: []float64{-18, 0, 18}, want: []float64{-1, 0, 1}},
+	} {
+		for i, v := range t.in {
+			got := t.t.Transform(v)
+			c.Check(math.Abs(got-t.want[i]) < 1e-12, check.Equals, true, check.Commentf("%T(%v) of %v: got:%v want:%v", t.t, t.t, v, got, t.want[i]))
+		}
+	}
+	// NegLog with no floor must remain finite at zero.
+	c.Check(math.IsInf(rings.NegLog(0).Transform(0), 0), check.Equals, false)
+
+	chr, lin := linearChr()
+	vals := []float64{1, 10, 100, 0}
+	set := makeScorers(chr, 4, 1, func(i, _ int) float64 { return vals[i] })
+
+	sp := &rings.Scatter{
+		GlyphStyles: []draw.GlyphStyle{{Color: color.Black, Radius: 1, Shape: draw.BoxGlyph{}}},
+		Transform:   rings.Log(0),
+		Min:         1,
+		Max:         100,
+		Axis: &rings.Axis{
+			Grid: plotter.DefaultGridLineStyle,
+			Tick: rings.TickConfig{Marker: plot.ConstantTicks([]plot.Tick{{Value: 1}, {Value: 10}, {Value: 100}})},
+		},
+	}
+	sc, err := rings.NewScores(set, lin, 0, 20, sp)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+
+	var grid, glyphs []vg.Point
+	for _, a := range tc.actions {
+		switch a := a.(type) {
+		case stroke:
+			grid = append(grid, a.path[0].Pos)
+		case fill:
+			glyphs = append(glyphs, a.path[0].Pos.Add(a.path[2].Pos).Scale(0.5))
+		}
+	}
+	c.Check(grid, check.DeepEquals, []vg.Point{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 0, Y: 20}})
+	c.Assert(len(glyphs), check.Equals, 3)
+	for i, want := range []vg.Point{{X: 12.5, Y: 0}, {X: 37.5, Y: 10}, {X: 62.5, Y: 20}} {
+		c.Check(math.Abs(float64(glyphs[i].X-want.X)) < 1e-10, check.Equals, true, check.Commentf("glyph %d x: %v", i, glyphs[i].X))
+		c.Check(math.Abs(float64(glyphs[i].Y-want.Y)) < 1e-10, check.Equals, true, check.Commentf("glyph %d y: %v", i, glyphs[i].Y))
+	}
+}
+
+func (s *S) TestHeatColorMap(c *check.C) {
+	chr, lin := linearChr()
+	vals := []float64{-2, -1, 0, 4, 5}
+	set := makeScorers(chr, 5, 1, func(i, _ int) float64 { return vals[i] })
+
+	under := moreland.SmoothBlueRed()
+	under.SetMax(1)
+	cm := rings.NewDiverging(under, -1, 0, 4)
+	h := &rings.Heat{ColorMap: cm, Underflow: color.Black, Overflow: color.White}
+	sc, err := rings.NewScores(set, lin, 10, 20, h)
+	c.Assert(err, check.Equals, nil)
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+
+	var got []color.Color
+	for _, a := range tc.actions {
+		if a, ok := a.(setColor); ok {
+			got = append(got, a.col)
+		}
+	}
+	at := func(v float64) color.Color {
+		col, err := cm.At(v)
+		c.Assert(err, check.Equals, nil)
+		return col
+	}
+	c.Check(got, check.DeepEquals, []color.Color{color.Black, at(-1), at(0), at(4), color.White})
+
+	// The center color lies at the middle of the underlying map,
+	// and the range ends at the ends of the map.
+	for _, v := range []struct{ div, under float64 }{{-1, 0}, {-0.5, 0.25}, {0, 0.5}, {2, 0.75}, {4, 1}} {
+		want, err := under.At(v.under)
+		c.Assert(err, check.Equals, nil)
+		c.Check(at(v.div), check.DeepEquals, want, check.Commentf("value %v", v.div))
+	}
+	c.Check(len(cm.Palette(5).Colors()), check.Equals, 5)
+
+	// The center must lie within the range.
+	c.Check(func() { rings.NewDiverging(under, 0, 5, 4) }, check.PanicMatches, `rings: diverging center 5 not in range \[0, 4\]`)
+	cm.SetCenter(-2)
+	_, err = cm.At(0)
+	c.Check(err, check.ErrorMatches, "rings: diverging center out of range")
+}
+
+func (s *S) TestHeatTracks(c *check.C) {
+	chr, lin := linearChr()
+	vals := [][]float64{{0, 15, 1}, {1, 25, math.NaN()}}
+	set := makeScorers(chr, 2, 3, func(i, j int) float64 { return vals[i][j] })
+
+	font, err := vg.MakeFont("Helvetica", 5)
+	c.Assert(err, check.Equals, nil)
+
+	gray := color.Gray{0x80}
+	red := color.RGBA{R: 0xff, A: 0xff}
+	h := &rings.Heat{
+		Palette:  []color.Color{color.Black, gray},
+		Overflow: red,
+		Tracks: []rings.HeatTrack{
+			{Width: 2, Label: "s1"},
+			{Palette: []color.Color{color.White}, Min: 10, Max: 20, Label: "s2"},
+		},
+		Gap:        4,
+		LabelStyle: draw.TextStyle{Color: color.Black, Font: font},
+		Min:        0,
+		Max:        1,
+	}
+	sc, err := rings.NewScores(set, lin, 10, 50, h)
+	c.Assert(err, check.Equals, nil)
+
+	// Relative widths of 2, 1 and 1 share the 32 units
+	// of radial space remaining after the gaps.
+	block := func(x0, x1, inner, outer vg.Length) interface{} {
+		return fill{path: vg.Path{mv(x0, inner), pt(x0, inner), pt(x1, inner), pt(x1, outer), pt(x0, outer), cl}}
+	}
+
+	tc := &canvas{dpi: defaultDPI}
+	sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	var (
+		fills []interface{}
+		text  []string
+	)
+	for _, act := range tc.actions {
+		switch act := act.(type) {
+		case fillString:
+			text = append(text, act.str)
+		default:
+			fills = append(fills, act)
+		}
+	}
+	c.Check(fills, check.DeepEquals, []interface{}{
+		setColor{col: color.Black},
+		block(0, 50, 10, 26),
+		setColor{col: color.White},
+		block(0, 50, 30, 38),
+		setColor{col: gray},
+		block(0, 50, 42, 50),
+
+		setColor{col: gray},
+		block(50, 100, 10, 26),
+		setColor{col: red},
+		block(50, 100, 30, 38),
+
+		// Label colors.
+		setColor{col: color.Gray16{Y: 0x0}},
+		setColor{col: color.Gray16{Y: 0x0}},
+	})
+	c.Check(text, check.DeepEquals, []string{"s1", "s2"})
+}
+
+func (s *S) TestColorBar(c *check.C) {
+	h := &rings.Heat{
+		Palette:   []color.Color{color.Black, color.Gray{0x80}, color.White},
+		Underflow: color.RGBA{B: 0xff, A: 0xff},
+		Overflow:  color.RGBA{R: 0xff, A: 0xff},
+		Min:       0,
+		Max:       2,
+	}
+	cb := rings.NewColorBar(h, 100, 10)
+	cb.Tick = rings.TickConfig{
+		LineStyle: plotter.DefaultLineStyle,
+		Length:    2,
+		Marker:    plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}, {Value: 2, Label: "2"}, {Value: 3, Label: "3"}}),
+	}
+
+	block := func(x0, x1 vg.Length) interface{} {
+		return fill{path: vg.Path{mv(x0, 0), pt(x1, 0), pt(x1, 10), pt(x0, 10), cl}}
+	}
+	tick := func(x vg.Length) interface{} { return stroke{path: vg.Path{mv(x, 0), pt(x, -2)}} }
+
+	tc := &canvas{dpi: defaultDPI}
+	cb.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		setColor{col: color.Black},
+		block(0, 25),
+		setColor{col: color.Gray{0x80}},
+		block(25, 75),
+		setColor{col: color.White},
+		block(75, 100),
+
+		// Underflow and Overflow swatches.
+		setColor{col: color.RGBA{B: 0xff, A: 0xff}},
+		block(-15, -5),
+		setColor{col: color.RGBA{R: 0xff, A: 0xff}},
+		block(105, 115),
+
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		tick(0),
+		tick(50),
+		tick(100),
+	})
+
+	// Ticks of a transformed ColorMap are marked in score values.
+	cm := moreland.SmoothBlueRed()
+	cm.SetMin(0)
+	cm.SetMax(2)
+	h = &rings.Heat{ColorMap: cm, Transform: rings.Log(0)}
+	cb = rings.NewColorBar(h, 100, 10)
+	cb.Tick = rings.TickConfig{
+		LineStyle: plotter.DefaultLineStyle,
+		Length:    2,
+		Marker:    plot.ConstantTicks([]plot.Tick{{Value: 1, Label: "1"}, {Value: 10, Label: "10"}, {Value: 100, Label: "100"}, {Value: 1000, Label: "1000"}}),
+	}
+	tc = &canvas{dpi: defaultDPI}
+	cb.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	var ticks []interface{}
+	for _, a := range tc.actions {
+		if a, ok := a.(stroke); ok {
+			ticks = append(ticks, a)
+		}
+	}
+	c.Check(ticks, check.DeepEquals, []interface{}{tick(0), tick(50), tick(100)})
+
+	h.Transform = rings.TransformFunc(math.Cbrt)
+	c.Check(func() { cb.DrawAt(draw.NewCanvas(&canvas{dpi: defaultDPI}, 300, 300), vg.Point{}) }, check.PanicMatches, `rings: cannot invert transform rings.TransformFunc for color bar ticks`)
+}
+
+func (s *S) TestRules(c *check.C) {
+	chr, lin := linearChr()
+	other := &fs{start: 0, end: 100, name: "other"}
+	a := &fs{start: 0, end: 10, name: "a1", location: chr}
+	b1 := &fs{start: 20, end: 50, name: "b1", location: chr}
+	b2 := &fs{start: 60, end: 70, name: "b2", location: chr}
+	d := &fs{start: 60, end: 70, name: "d", location: other}
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	for i, t := range []struct {
+		pred rings.Predicate
+		item rings.Item
+		want bool
+	}{
+		{pred: rings.NameMatches(regexp.MustCompile("^b")), item: rings.Item{Feature: b1}, want: true},
+		{pred: rings.NameMatches(regexp.MustCompile("^b")), item: rings.Item{Feature: a}, want: false},
+		{pred: rings.NameMatches(regexp.MustCompile("^b")), item: rings.Item{Pair: fp{feats: [2]*fs{a, b2}}}, want: true},
+		{pred: rings.LabelMatches(regexp.MustCompile("^chr")), item: rings.Item{Label: "chr1"}, want: true},
+		{pred: rings.OnLocation("other"), item: rings.Item{Feature: d}, want: true},
+		{pred: rings.OnLocation("other"), item: rings.Item{Feature: a}, want: false},
+		{pred: rings.LengthIn(10, 20), item: rings.Item{Feature: a}, want: true},
+		{pred: rings.LengthIn(10, 20), item: rings.Item{Pair: fp{feats: [2]*fs{a, b1}}}, want: false},
+		{pred: rings.LengthIn(10, 20), item: rings.Item{}, want: false},
+		{pred: rings.ValueIn(1, 2), item: rings.Item{Value: 1.5}, want: true},
+		{pred: rings.ValueIn(1, 2), item: rings.Item{Value: math.NaN()}, want: false},
+		{pred: rings.SameLocation, item: rings.Item{Pair: fp{feats: [2]*fs{a, b2}}}, want: true},
+		{pred: rings.SameLocation, item: rings.Item{Pair: fp{feats: [2]*fs{a, d}}}, want: false},
+		{pred: rings.SameLocation, item: rings.Item{Feature: a}, want: false},
+		{pred: rings.PairDistance(0, 60), item: rings.Item{Pair: fp{feats: [2]*fs{a, b2}}}, want: true},
+		{pred: rings.PairDistance(0, 50), item: rings.Item{Pair: fp{feats: [2]*fs{a, b2}}}, want: false},
+		{pred: rings.PairDistance(0, 100), item: rings.Item{Pair: fp{feats: [2]*fs{a, d}}}, want: false},
+		{pred: rings.And(rings.OnLocation("chr"), rings.Not(rings.LengthIn(0, 10))), item: rings.Item{Feature: b1}, want: true},
+		{pred: rings.And(rings.OnLocation("chr"), rings.Not(rings.LengthIn(0, 10))), item: rings.Item{Feature: b2}, want: false},
+		{pred: rings.Or(rings.OnLocation("other"), rings.LengthIn(0, 10)), item: rings.Item{Feature: b1}, want: false},
+		{pred: rings.Or(rings.OnLocation("other"), rings.LengthIn(0, 10)), item: rings.Item{Feature: b2}, want: true},
+	} {
+		c.Check(t.pred(t.item), check.Equals, t.want, check.Commentf("Test %d", i))
+	}
+
+	rules := []rings.Rule{
+		{Condition: rings.NameMatches(regexp.MustCompile("^a")), Hide: true},
+		{Condition: rings.NameMatches(regexp.MustCompile("^b")), Apply: func(s *rings.Style) { s.Fill = red }},
+		{Condition: rings.NameMatches(regexp.MustCompile("2$")), Apply: func(s *rings.Style) { s.Line = plotter.DefaultLineStyle }},
+	}
+	for i, t := range []struct {
+		cumulative bool
+		want       []interface{}
+	}{
+		{
+			cumulative: false,
+			want:       []interface{}{setColor{col: red}, setColor{col: red}},
+		},
+		{
+			cumulative: true,
+			want:       []interface{}{setColor{col: red}, setColor{col: red}, setColor{col: color.Gray16{Y: 0x0}}},
+		},
+	} {
+		b, err := rings.NewBlocks([]feat.Feature{a, b1, b2}, lin, 10, 20)
+		c.Assert(err, check.Equals, nil)
+		b.Color = color.Black
+		b.Rules = &rings.Rules{Rules: rules, Cumulative: t.cumulative}
+
+		tc := &canvas{dpi: defaultDPI}
+		b.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []interface{}
+		for _, act := range tc.actions {
+			if _, ok := act.(setColor); ok {
+				got = append(got, act)
+			}
+		}
+		c.Check(got, check.DeepEquals, t.want, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestScoresBins(c *check.C) {
+	chr, lin := linearChr()
+	set := makeScorers(chr, 10, 2, func(i, j int) float64 {
+		if j == 1 {
+			if i < 5 {
+				return math.NaN()
+			}
+			return float64(10 - i)
+		}
+		return float64(i)
+	})
+
+	type bin struct {
+		arc    rings.Arc
+		name   string
+		scores []float64
+	}
+	r := &recRenderer{}
+	sc, err := rings.NewScores(set, lin, 10, 20, r)
+	c.Assert(err, check.Equals, nil)
+
+	nan := math.NaN()
+	for i, t := range []struct {
+		bins     *rings.Bins
+		want     []bin
+		min, max float64
+	}{
+		{
+			bins: &rings.Bins{Width: 30, Reduce: rings.MaxReducer},
+			want: []bin{
+				{arc: rings.Arc{0, 30}, name: "chr:0-30", scores: []float64{2, nan}},
+				{arc: rings.Arc{30, 30}, name: "chr:30-60", scores: []float64{5, 5}},
+				{arc: rings.Arc{60, 30}, name: "chr:60-90", scores: []float64{8, 4}},
+				{arc: rings.Arc{90, 10}, name: "chr:90-100", scores: []float64{9, 1}},
+			},
+			min: 1, max: 9,
+		},
+		{
+			bins: &rings.Bins{Width: 50, Reduce: rings.MedianReducer},
+			want: []bin{
+				{arc: rings.Arc{0, 50}, name: "chr:0-50", scores: []float64{2, nan}},
+				{arc: rings.Arc{50, 50}, name: "chr:50-100", scores: []float64{7, 3}},
+			},
+			min: 2, max: 7,
+		},
+		{
+			bins: &rings.Bins{Width: 50, Reduce: rings.SumReducer},
+			want: []bin{
+				{arc: rings.Arc{0, 50}, name: "chr:0-50", scores: []float64{10, nan}},
+				{arc: rings.Arc{50, 50}, name: "chr:50-100", scores: []float64{35, 15}},
+			},
+			// The score range includes sums outside the unbinned range.
+			min: 10, max: 35,
+		},
+		{
+			// Scorers overlapping bin boundaries contribute to both bins.
+			bins: &rings.Bins{Resolution: 25},
+			want: []bin{
+				{arc: rings.Arc{0, 25}, name: "chr:0-25", scores: []float64{1, nan}},
+				{arc: rings.Arc{25, 25}, name: "chr:25-50", scores: []float64{3, nan}},
+				{arc: rings.Arc{50, 25}, name: "chr:50-75", scores: []float64{6, 4}},
+				{arc: rings.Arc{75, 25}, name: "chr:75-100", scores: []float64{8, 2}},
+			},
+			min: 1, max: 8,
+		},
+	} {
+		sc.Bins = t.bins
+		sc.DrawAt(draw.Canvas{}, vg.Point{})
+
+		// The binned range is passed to the Renderer
+		// without altering the range of the Scores.
+		c.Check(sc.Min, check.Equals, 0.0, check.Commentf("Test %d", i))
+		c.Check(sc.Max, check.Equals, 9.0, check.Commentf("Test %d", i))
+		c.Check(r.min, check.Equals, t.min, check.Commentf("Test %d", i))
+		c.Check(r.max, check.Equals, t.max, check.Commentf("Test %d", i))
+
+		c.Check(len(r.arcs), check.Equals, len(t.want), check.Commentf("Test %d", i))
+		for k := 0; k < len(r.arcs) && k < len(t.want); k++ {
+			c.Check(r.arcs[k], check.Equals, t.want[k].arc, check.Commentf("Test %d bin %d", i, k))
+			c.Check(r.scorers[k].Name(), check.Equals, t.want[k].name, check.Commentf("Test %d bin %d", i, k))
+			got := r.scorers[k].Scores()
+			c.Check(len(got), check.Equals, len(t.want[k].scores), check.Commentf("Test %d bin %d", i, k))
+			for j, v := range t.want[k].scores {
+				if math.IsNaN(v) {
+					c.Check(math.IsNaN(got[j]), check.Equals, true, check.Commentf("Test %d bin %d series %d", i, k, j))
+					continue
+				}
+				c.Check(got[j], check.Equals, v, check.Commentf("Test %d bin %d series %d", i, k, j))
+			}
+		}
+	}
+
+	sc.Bins = nil
+	sc.DrawAt(draw.Canvas{}, vg.Point{})
+	c.Check(r.min, check.Equals, 0.0)
+	c.Check(r.max, check.Equals, 9.0)
+
+	sc.Bins = &rings.Bins{Width: -1}
+	c.Check(func() { sc.DrawAt(draw.Canvas{}, vg.Point{}) }, check.PanicMatches, "rings: cannot bin scores:rings: negative bin width")
+
+	// Scorers without a location are not binned.
+	whole := &fs{start: 0, end: 100, name: "whole", scores: []float64{20}}
+	base := rings.NewLinearArcs(rings.Arc{0, 200}, []feat.Feature{chr, whole}, rings.UniformGap(0), 200)
+	sc, err = rings.NewScores(append(makeScorers(chr, 2, 1, func(i, _ int) float64 { return float64(i) }), whole), base, 10, 20, r)
+	c.Assert(err, check.Equals, nil)
+	sc.Bins = &rings.Bins{Width: 100, Reduce: rings.SumReducer}
+	sc.DrawAt(draw.Canvas{}, vg.Point{})
+	c.Check(r.arcs, check.DeepEquals, []rings.Arc{{0, 100}, {100, 100}})
+	c.Check(r.scorers[1], check.Equals, rings.Scorer(whole))
+	c.Check(r.min, check.Equals, 1.0)
+	c.Check(r.max, check.Equals, 20.0)
+}
+
+func (s *S) TestRadialAxis(c *check.C) {
+	chr := []*fs{
+		{start: 0, end: 100, name: "A"},
+		{start: 0, end: 100, name: "B"},
+	}
+	lin := rings.NewLinearArcs(rings.Arc{0, 200}, []feat.Feature{chr[0], chr[1]}, rings.UniformGap(0), 200)
+	var set []rings.Scorer
+	for _, f := range chr {
+		set = append(set, makeScorers(f, 5, 1, func(i, _ int) float64 { return float64(i) })...)
+	}
+	sc, err := rings.NewScores(set, lin, 10, 50, &rings.Heat{Palette: []color.Color{color.Black, color.White}})
+	c.Assert(err, check.Equals, nil)
+
+	gray := color.Gray{0x80}
+	light := color.Gray{0xc0}
+	ax := rings.NewRadialAxis(sc, rings.Axis{
+		LineStyle: plotter.DefaultLineStyle,
+		Grid:      draw.LineStyle{Color: gray, Width: 1},
+		MinorGrid: draw.LineStyle{Color: light, Width: 0.5},
+		Tick: rings.TickConfig{
+			Marker: plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 2}, {Value: 4, Label: "4"}}),
+		},
+	}, 150)
+	ax.AtLocations = true
+
+	grid := func(x0, x1, y vg.Length) interface{} { return stroke{path: vg.Path{mv(x0, y), pt(x0, y), pt(x1, y)}} }
+	spoke := func(x vg.Length) interface{} { return stroke{path: vg.Path{mv(x, 10), pt(x, 50)}} }
+
+	tc := &canvas{dpi: defaultDPI}
+	ax.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+	c.Check(tc.actions, check.DeepEquals, []interface{}{
+		// Major and minor grid lines for A.
+		setColor{col: gray},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		grid(0, 100, 10),
+		grid(0, 100, 50),
+		setColor{col: light},
+		setWidth{w: 0.5},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		grid(0, 100, 30),
+
+		// Major and minor grid lines for B.
+		setColor{col: gray},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		grid(100, 200, 10),
+		grid(100, 200, 50),
+		setColor{col: light},
+		setWidth{w: 0.5},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		grid(100, 200, 30),
+
+		// Axes at the specified angle and then at the start of A and B.
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		spoke(150),
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		spoke(0),
+		setColor{col: color.Gray16{Y: 0x0}},
+		setWidth{w: 1},
+		setLineDash{dashes: []vg.Length(nil), offsets: 0},
+		spoke(100),
+	})
+}
+
+func (s *S) TestRadialAxisRenderer(c *check.C) {
+	chr, lin := linearChr()
+	set := makeScorers(chr, 4, 1, func(i, _ int) float64 { return float64(i) })
+
+	h := &rings.Histogram{Colors: []color.Color{color.Black}, Inward: true, Min: -2, Max: 4}
+	sc, err := rings.NewScores(set, lin, 10, 40, h)
+	c.Assert(err, check.Equals, nil)
+
+	gray := color.Gray{0x80}
+	ticks := plot.ConstantTicks([]plot.Tick{{Value: -2, Label: "-2"}, {Value: 1, Label: "1"}, {Value: 4, Label: "4"}})
+	for i, t := range []struct {
+		min, max float64
+		want     []vg.Length
+	}{
+		// The range and orientation of the Histogram are used.
+		{want: []vg.Length{40, 25, 10}},
+
+		// The range of the RadialAxis overrides the Histogram's.
+		{min: -2, max: 10, want: []vg.Length{40, 32.5, 25}},
+	} {
+		ax := rings.NewRadialAxis(sc, rings.Axis{
+			Grid: draw.LineStyle{Color: gray, Width: 1},
+			Tick: rings.TickConfig{Marker: ticks},
+		})
+		ax.Min, ax.Max = t.min, t.max
+
+		tc := &canvas{dpi: defaultDPI}
+		ax.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []vg.Length
+		for _, a := range tc.actions {
+			if a, ok := a.(stroke); ok {
+				got = append(got, a.path[0].Pos.Y)
+			}
+		}
+		c.Check(got, check.DeepEquals, t.want, check.Commentf("Test %d", i))
+	}
+
+	// The axis scale does not depend on whether the axis is drawn
+	// before or after the Scores, including when the Scores' range
+	// is altered by binning.
+	sc, err = rings.NewScores(set, lin, 10, 40, &rings.Histogram{Colors: []color.Color{color.Black}})
+	c.Assert(err, check.Equals, nil)
+	sc.Bins = &rings.Bins{Width: 50, Reduce: rings.SumReducer}
+	ticks = plot.ConstantTicks([]plot.Tick{{Value: 1, Label: "1"}, {Value: 5, Label: "5"}})
+	ax := rings.NewRadialAxis(sc, rings.Axis{
+		Grid: draw.LineStyle{Color: gray, Width: 1},
+		Tick: rings.TickConfig{Marker: ticks},
+	})
+	for _, order := range []string{"before", "after"} {
+		if order == "after" {
+			sc.DrawAt(draw.NewCanvas(&canvas{dpi: defaultDPI}, 300, 300), vg.Point{})
+		}
+		tc := &canvas{dpi: defaultDPI}
+		ax.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []vg.Length
+		for _, a := range tc.actions {
+			if a, ok := a.(stroke); ok {
+				got = append(got, a.path[0].Pos.Y)
+			}
+		}
+		c.Check(got, check.DeepEquals, []vg.Length{10, 40}, check.Commentf("axis drawn %s Scores", order))
+	}
+}
+
+func (s *S) TestScaleZoom(c *check.C) {
+	chr := &fs{start: 0, end: 100, name: "chr"}
+	for i, t := range []struct {
+		zooms []rings.Zoom
+		want  []rings.Angle
+	}{
+		{
+			zooms: nil,
+			want:  []rings.Angle{0, 0.5 * rings.Complete, 0.55 * rings.Complete, 0.6 * rings.Complete, rings.Complete},
+		},
+		{
+			// The tick at 55 moves with the zoomed region
+			// and ticks after the region are pushed along.
+			zooms: []rings.Zoom{{Feature: chr, Start: 50, End: 60, Scale: 10}},
+			want:  []rings.Angle{0, 50.0 / 190 * rings.Complete, 100.0 / 190 * rings.Complete, 150.0 / 190 * rings.Complete, rings.Complete},
+		},
+	} {
+		base, err := rings.NewZoomedArcs(rings.Arc{0, rings.Complete}, []feat.Feature{chr}, 0, t.zooms)
+		c.Assert(err, check.Equals, nil)
+		sc, err := rings.NewScale([]feat.Feature{chr}, base, 100)
+		c.Assert(err, check.Equals, nil)
+		sc.Tick = rings.TickConfig{
+			LineStyle: plotter.DefaultLineStyle,
+			Length:    2,
+			Marker:    plot.ConstantTicks([]plot.Tick{{Value: 0, Label: "0"}, {Value: 50, Label: "50"}, {Value: 55, Label: "55"}, {Value: 60, Label: "60"}, {Value: 100, Label: "100"}}),
+		}
+		sc.LineStyle = plotter.DefaultLineStyle
+
+		tc := &canvas{dpi: defaultDPI}
+		sc.DrawAt(draw.NewCanvas(tc, 300, 300), vg.Point{})
+		var got []vg.Point
+		for _, act := range tc.actions {
+			if s, ok := act.(stroke); ok && len(s.path) == 2 && s.path[1].Type == vg.LineComp {
+				got = append(got, s.path[0].Pos)
+			}
+		}
+		c.Assert(len(got), check.Equals, len(t.want), check.Commentf("Test %d", i))
+		for k, theta := range t.want {
+			want := rings.Rectangular(theta, 100)
+			c.Check(math.Abs(float64(got[k].X-want.X)) < 1e-9 && math.Abs(float64(got[k].Y-want.Y)) < 1e-9, check.Equals, true,
+				check.Commentf("Test %d tick %d: got:%v want:%v", i, k, got[k], want))
+		}
+	}
+}
+
+// arcOfer hides any ArcsOf method of the embedded ArcOfer.
+type arcOfer struct{ rings.ArcOfer }
+
+// nopRenderer is a ScoreRenderer that does not render.
+type nopRenderer struct{ n int }
+
+func (r *nopRenderer) Configure(draw.Canvas, vg.Point, rings.ArcOfer, vg.Length, vg.Length, float64, float64) {
+	r.n = 0
+}
+func (r *nopRenderer) Render(rings.Arc, rings.Scorer) { r.n++ }
+func (r *nopRenderer) Close()                         {}
+
+// recRenderer is a ScoreRenderer that records the arcs and Scorers it is asked to render.
+type recRenderer struct {
+	min, max float64
+	arcs     []rings.Arc
+	scorers  []rings.Scorer
+}
+
+func (r *recRenderer) Configure(_ draw.Canvas, _ vg.Point, _ rings.ArcOfer, _, _ vg.Length, min, max float64) {
+	r.min, r.max = min, max
+	r.arcs, r.scorers = nil, nil
+}
+func (r *recRenderer) Render(arc rings.Arc, s rings.Scorer) {
+	r.arcs = append(r.arcs, arc)
+	r.scorers = append(r.scorers, s)
+}
+func (r *recRenderer) Close() {}
+
+func BenchmarkScoresDrawAt(b *testing.B) {
+	const n = 1e6
+
+	rand.Seed(1)
+	chr := randomFeatures(3, 100000, 1000000, false, plotter.DefaultLineStyle)
+	base := rings.NewGappedArcs(rings.Arc{0, rings.Complete * rings.Clockwise}, chr, 0.01)
+	var set []rings.Scorer
+	for _, f := range chr {
+		set = append(set, makeScorers(f.(*fs), n/len(chr), 1, func(i, _ int) float64 { return float64(i) })...)
+	}
+
+	for _, bench := range []struct {
+		name string
+		base rings.ArcOfer
+	}{
+		{name: "ArcOf", base: arcOfer{base}},
+		{name: "ArcsOf", base: base},
+	} {
+		r := &nopRenderer{}
+		sc, err := rings.NewScores(set, bench.base, 40, 75, r)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sc.DrawAt(draw.Canvas{}, vg.Point{})
+			}
+			if r.n != len(set) {
+				b.Fatalf("unexpected number of rendered features: got:%d want:%d", r.n, len(set))
+			}
+		})
+	}
+}
+
+func BenchmarkArcsOf(b *testing.B) {
+	const n = 1e6
+
+	rand.Seed(1)
+	chr := randomFeatures(3, 100000, 1000000, false, plotter.DefaultLineStyle)
+	base := rings.NewGappedArcs(rings.Arc{0, rings.Complete * rings.Clockwise}, chr, 0.01)
+	var feats []feat.Feature
+	for _, f := range chr {
+		for _, s := range makeScorers(f.(*fs), n/len(chr), 1, func(i, _ int) float64 { return float64(i) }) {
+			feats = append(feats, s)
+		}
+	}
+	arcs := make([]rings.Arc, 0, len(feats))
+
+	b.Run("ArcOf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			arcs = arcs[:0]
+			for _, f := range feats {
+				arc, err := base.ArcOf(f.Location(), f)
+				if err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+				arcs = append(arcs, arc)
+			}
+		}
+	})
+	b.Run("ArcsOf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var err error
+			arcs, err = base.ArcsOf(arcs[:0], feats)
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
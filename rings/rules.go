@@ -0,0 +1,245 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"image/color"
+	"math"
+	"regexp"
+
+	"gonum.org/v1/plot/vg/draw"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// Item describes an element of a ring that is being styled by Rules.
+type Item struct {
+	// Feature is the feature being rendered. For items rendered by a
+	// ScoreRenderer Feature is the Scorer holding the score value, and
+	// for items rendered by Links and Ribbons Feature is nil.
+	Feature feat.Feature
+
+	// Pair is the feature pair being rendered by Links and Ribbons.
+	// For other items Pair is nil.
+	Pair Pair
+
+	// Index and Value are the index and value of the score being rendered
+	// by a ScoreRenderer. For other items Index is zero and Value is NaN.
+	Index int
+	Value float64
+
+	// Label is the text being rendered by Labels.
+	Label string
+}
+
+// featureItem returns an Item for the feature f.
+func featureItem(f feat.Feature) Item { return Item{Feature: f, Value: math.NaN()} }
+
+// pairItem returns an Item for the feature pair p.
+func pairItem(p Pair) Item { return Item{Pair: p, Value: math.NaN()} }
+
+// Style holds the style of an Item. Not all fields are used by every ring type.
+type Style struct {
+	// Fill is the fill color of the item.
+	Fill color.Color
+
+	// Line is the line style of the item.
+	Line draw.LineStyle
+
+	// Text is the text style of the item.
+	Text draw.TextStyle
+
+	// Glyph is the glyph style of the item.
+	Glyph draw.GlyphStyle
+}
+
+// Predicate is a condition on an Item.
+type Predicate func(Item) bool
+
+// Rule is a styling rule.
+type Rule struct {
+	// Condition determines whether the rule applies to an item.
+	// If Condition is nil, the rule applies to all items.
+	Condition Predicate
+
+	// Apply alters the style of items the rule applies to. If Apply
+	// is nil, the style is not altered.
+	Apply func(*Style)
+
+	// Hide specifies that items the rule applies to are not rendered.
+	Hide bool
+}
+
+// Rules is an ordered list of styling rules, analogous to the rules blocks of Circos.
+type Rules struct {
+	// Rules holds the rules in order of evaluation.
+	Rules []Rule
+
+	// Cumulative specifies that every rule that applies to an item is applied in
+	// order. Otherwise only the first rule that applies to an item is applied.
+	Cumulative bool
+}
+
+// apply returns the style of it after applying the rules to sty, and whether the item is
+// visible. If the receiver is nil, sty is returned unaltered.
+func (r *Rules) apply(it Item, sty Style) (Style, bool) {
+	if r == nil {
+		return sty, true
+	}
+	for _, rule := range r.Rules {
+		if rule.Condition != nil && !rule.Condition(it) {
+			continue
+		}
+		if rule.Hide {
+			return sty, false
+		}
+		if rule.Apply != nil {
+			rule.Apply(&sty)
+		}
+		if !r.Cumulative {
+			break
+		}
+	}
+	return sty, true
+}
+
+// And returns a Predicate that is true when all of ps are true.
+func And(ps ...Predicate) Predicate {
+	return func(it Item) bool {
+		for _, p := range ps {
+			if !p(it) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate that is true when any of ps is true.
+func Or(ps ...Predicate) Predicate {
+	return func(it Item) bool {
+		for _, p := range ps {
+			if p(it) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate that is true when p is false.
+func Not(p Predicate) Predicate {
+	return func(it Item) bool { return !p(it) }
+}
+
+// features returns the features described by it.
+func (it Item) features() []feat.Feature {
+	if it.Pair != nil {
+		p := it.Pair.Features()
+		return p[:]
+	}
+	if it.Feature != nil {
+		return []feat.Feature{it.Feature}
+	}
+	return nil
+}
+
+// NameMatches returns a Predicate that is true when the name of an item's feature, or of
+// either feature of an item's pair, matches re.
+func NameMatches(re *regexp.Regexp) Predicate {
+	return func(it Item) bool {
+		for _, f := range it.features() {
+			if re.MatchString(f.Name()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// LabelMatches returns a Predicate that is true when an item's label matches re.
+func LabelMatches(re *regexp.Regexp) Predicate {
+	return func(it Item) bool { return re.MatchString(it.Label) }
+}
+
+// OnLocation returns a Predicate that is true when an item's feature, or either feature of
+// an item's pair, is located on a feature with the specified name.
+func OnLocation(name string) Predicate {
+	return func(it Item) bool {
+		for _, f := range it.features() {
+			if loc := f.Location(); loc != nil && loc.Name() == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// LengthIn returns a Predicate that is true when the length of an item's feature is within
+// the closed interval [min, max]. For pairs, both features must have lengths within the interval.
+func LengthIn(min, max int) Predicate {
+	return func(it Item) bool {
+		fs := it.features()
+		for _, f := range fs {
+			if l := f.Len(); l < min || max < l {
+				return false
+			}
+		}
+		return len(fs) != 0
+	}
+}
+
+// ValueIn returns a Predicate that is true when an item's score value is within the closed
+// interval [min, max].
+func ValueIn(min, max float64) Predicate {
+	return func(it Item) bool { return min <= it.Value && it.Value <= max }
+}
+
+// SameLocation is a Predicate that is true when both features of an item's pair
+// are located on the same feature.
+func SameLocation(it Item) bool {
+	if it.Pair == nil {
+		return false
+	}
+	p := it.Pair.Features()
+	return p[0].Location() == p[1].Location()
+}
+
+// PairDistance returns a Predicate that is true when both features of an item's pair are
+// located on the same feature and the distance between their centers is within the closed
+// interval [min, max].
+func PairDistance(min, max int) Predicate {
+	return func(it Item) bool {
+		if !SameLocation(it) {
+			return false
+		}
+		p := it.Pair.Features()
+		d := math.Abs(float64(p[0].Start()+p[0].End()-p[1].Start()-p[1].End()) / 2)
+		return float64(min) <= d && d <= float64(max)
+	}
+}
+
+// sameColor returns whether a and b are the same color.
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+// sameLineStyle returns whether a and b are the same line style.
+func sameLineStyle(a, b draw.LineStyle) bool {
+	if !sameColor(a.Color, b.Color) || a.Width != b.Width || a.DashOffs != b.DashOffs || len(a.Dashes) != len(b.Dashes) {
+		return false
+	}
+	for i, d := range a.Dashes {
+		if d != b.Dashes[i] {
+			return false
+		}
+	}
+	return true
+}
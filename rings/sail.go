@@ -75,13 +75,14 @@ type Sail struct {
 
 // NewSail returns a Sail based on the parameters, first checking that the provided features
 // are able to be rendered. An error is returned if the features are not renderable. The base of
-// a Sail ring cannot be an Arc or a Highlight.
+// a Sail ring cannot be an Arc or a Highlight. Features outside the display windows of base are
+// not rendered.
 func NewSail(fs []feat.Feature, base ArcOfer, r vg.Length) (*Sail, error) {
 	for _, f := range fs {
 		if f.End() < f.Start() {
 			return nil, errors.New("rings: inverted feature")
 		}
-		if _, err := base.ArcOf(nil, f); err != nil {
+		if _, err := base.ArcOf(nil, f); err != nil && err != ErrOutsideWindows {
 			return nil, err
 		}
 	}
@@ -194,6 +195,10 @@ func (r *Sail) DrawAt(ca draw.Canvas, cen vg.Point) {
 
 		af[j].Feature = f
 		arc, err := r.Base.ArcOf(loc, f)
+		if err == ErrOutsideWindows {
+			j--
+			continue
+		}
 		if err != nil {
 			panic(fmt.Sprint("rings: no arc for feature location:", err))
 		}
@@ -272,6 +277,9 @@ func (r *Sail) DrawAt(ca draw.Canvas, cen vg.Point) {
 	}
 }
 
+// Projection returns the projection of the Sail's Base.
+func (r *Sail) Projection() Projection { return projectionOf(r.Base) }
+
 // Plot calls DrawAt using the Sail's X and Y values as the drawing coordinates.
 func (r *Sail) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -305,6 +313,10 @@ func (r *Sail) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 
 			af[j].Feature = f
 			arc, err := r.Base.ArcOf(loc, f)
+			if err == ErrOutsideWindows {
+				j--
+				continue
+			}
 			if err != nil {
 				panic(fmt.Sprint("rings: no arc for feature location:", err))
 			}
@@ -80,6 +80,7 @@ func (r *Scale) DrawAt(ca draw.Canvas, cen vg.Point) {
 		return
 	}
 
+	proj := projectionOf(r.Base)
 	var pa vg.Path
 	for _, f := range r.Set {
 		pa = pa[:0]
@@ -96,24 +97,37 @@ func (r *Scale) DrawAt(ca draw.Canvas, cen vg.Point) {
 		if err != nil {
 			panic(fmt.Sprint("rings: no arc for feature location:", err))
 		}
-		scale := arc.Phi / Angle(max-min)
 
-		// These loops are split to reduce the amount of style changing between elements.
+		// Tick marks are placed using the mapping of the Base so that
+		// non-uniform mappings are respected. Marks at positions that
+		// are not mapped by the Base are not rendered.
 		marks := r.Tick.Marker.Ticks(float64(f.Start()), float64(f.End()))
+		angles := make([]Angle, len(marks))
+		for i, mark := range marks {
+			iv := int(mark.Value)
+			if iv < min || iv > max {
+				continue
+			}
+			angles[i], err = angleOf(r.Base, f, iv)
+			if err != nil {
+				angles[i] = angleNaN
+			}
+		}
+
+		// These loops are split to reduce the amount of style changing between elements.
 
 		if r.Grid.Inner != r.Grid.Outer && r.Grid.LineStyle.Color != nil && r.Grid.LineStyle.Width != 0 {
 			ca.SetLineStyle(r.Grid.LineStyle)
-			for _, mark := range marks {
+			for i, mark := range marks {
 				iv := int(mark.Value)
-				if iv < f.Start() || iv > f.End() {
+				angle := angles[i]
+				if iv < f.Start() || iv > f.End() || math.IsNaN(float64(angle)) {
 					continue
 				}
 				pa = pa[:0]
 
-				angle := Angle(iv-min)*scale + arc.Theta
-
-				pa.Move(cen.Add(Rectangular(angle, r.Grid.Inner)))
-				pa.Line(cen.Add(Rectangular(angle, r.Grid.Outer)))
+				pa.Move(cen.Add(proj.Point(angle, r.Grid.Inner)))
+				pa.Line(cen.Add(proj.Point(angle, r.Grid.Outer)))
 
 				ca.Stroke(pa)
 			}
@@ -121,10 +135,10 @@ func (r *Scale) DrawAt(ca draw.Canvas, cen vg.Point) {
 
 		if r.LineStyle.Color != nil && r.LineStyle.Width != 0 {
 			start := arc.Theta
-			end := Angle(f.End()-min)*scale + arc.Theta
+			end := Angle(f.End()-min)*(arc.Phi/Angle(max-min)) + arc.Theta
 			pa = pa[:0]
-			pa.Move(cen.Add(Rectangular(start, r.Radius)))
-			pa.Arc(cen, r.Radius, float64(start), float64(end-start))
+			pa.Move(cen.Add(proj.Point(start, r.Radius)))
+			proj.Arc(&pa, cen, r.Radius, start, end-start)
 
 			ca.SetLineStyle(r.LineStyle)
 			ca.Stroke(pa)
@@ -132,45 +146,44 @@ func (r *Scale) DrawAt(ca draw.Canvas, cen vg.Point) {
 
 		if r.Tick.LineStyle.Color != nil && r.Tick.LineStyle.Width != 0 && r.Tick.Length != 0 {
 			ca.SetLineStyle(r.LineStyle)
-			for _, mark := range marks {
+			for i, mark := range marks {
 				iv := int(mark.Value)
-				if iv < f.Start() || iv > f.End() {
+				angle := angles[i]
+				if iv < f.Start() || iv > f.End() || math.IsNaN(float64(angle)) {
 					continue
 				}
 				pa = pa[:0]
 
-				angle := Angle(iv-min)*scale + arc.Theta
-
 				var length vg.Length
 				if mark.IsMinor() {
 					length = r.Tick.Length / 2
 				} else {
 					length = r.Tick.Length
 				}
-				pa.Move(cen.Add(Rectangular(angle, r.Radius)))
-				pa.Line(cen.Add(Rectangular(angle, r.Radius+length)))
+				pa.Move(cen.Add(proj.Point(angle, r.Radius)))
+				pa.Line(cen.Add(proj.Point(angle, r.Radius+length)))
 
 				ca.Stroke(pa)
 			}
 		}
 
 		if r.Tick.Label.Color != nil {
-			for _, mark := range marks {
+			for i, mark := range marks {
 				iv := int(mark.Value)
-				if iv < f.Start() || iv > f.End() || mark.IsMinor() {
+				angle := angles[i]
+				if iv < f.Start() || iv > f.End() || mark.IsMinor() || math.IsNaN(float64(angle)) {
 					continue
 				}
 
-				angle := Angle(iv-min)*scale + arc.Theta
-				pt := cen.Add(Rectangular(angle, r.Radius+r.Tick.Length+r.Tick.Label.Font.Extents().Height))
+				pt := cen.Add(proj.Point(angle, r.Radius+r.Tick.Length+r.Tick.Label.Font.Extents().Height))
 				var (
 					rot            Angle
 					xalign, yalign float64
 				)
 				if r.Tick.Placement == nil {
-					rot, xalign, yalign = DefaultPlacement(angle)
+					rot, xalign, yalign = DefaultPlacement(proj.Normal(angle))
 				} else {
-					rot, xalign, yalign = r.Tick.Placement(angle)
+					rot, xalign, yalign = r.Tick.Placement(proj.Normal(angle))
 				}
 				r.Tick.Label.XAlign = draw.XAlignment(xalign)
 				r.Tick.Label.YAlign = draw.YAlignment(yalign)
@@ -182,6 +195,9 @@ func (r *Scale) DrawAt(ca draw.Canvas, cen vg.Point) {
 	}
 }
 
+// Projection returns the projection of the Scale's Base.
+func (r *Scale) Projection() Projection { return projectionOf(r.Base) }
+
 // Plot calls DrawAt using the Scale's X and Y values as the drawing coordinates.
 func (r *Scale) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -194,11 +210,8 @@ func (r *Scale) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	radius := math.Max(float64(r.Radius+r.Tick.Length), grid)
 	radius = math.Max(radius, float64(r.Tick.Label.Font.Extents().Height*2))
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-vg.Length(radius), -vg.Length(radius)},
-			Max: vg.Point{vg.Length(radius), vg.Length(radius)},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(vg.Length(radius)),
 	}}
 }
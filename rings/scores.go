@@ -12,6 +12,7 @@ import (
 	"sort"
 
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 
@@ -53,21 +54,29 @@ type Scores struct {
 	// feature sets score data.
 	Renderer ScoreRenderer
 
-	// Min and Max hold the score range.
+	// Min and Max hold the score range. If Bins is not nil, the
+	// range of the binned scores is used for rendering instead.
 	Min, Max float64
 
 	// Inner and Outer define the inner and outer radii of the blocks.
 	Inner, Outer vg.Length
 
+	// Bins specifies the aggregation of the Scorers in Set into windows
+	// before they are passed to the Renderer. If Bins is nil, each Scorer
+	// is rendered individually.
+	Bins *Bins
+
 	// X and Y specify rendering location when Plot is called.
 	X, Y float64
 }
 
 // NewScores returns a Scores based on the parameters, first checking that the provided features
-// are able to be rendered. An error is returned if the features are not renderable.
+// are able to be rendered. An error is returned if the features are not renderable. Features
+// outside the display windows of base are not rendered.
 func NewScores(fs []Scorer, base ArcOfer, inner, outer vg.Length, renderer ScoreRenderer) (*Scores, error) {
 	min, max := math.Inf(1), math.Inf(-1)
-	for _, f := range fs {
+	feats := make([]feat.Feature, len(fs))
+	for i, f := range fs {
 		if f.End() < f.Start() {
 			return nil, errors.New("rings: inverted feature")
 		}
@@ -76,9 +85,7 @@ func NewScores(fs []Scorer, base ArcOfer, inner, outer vg.Length, renderer Score
 				return nil, errors.New("rings: feature out of range")
 			}
 		}
-		if _, err := base.ArcOf(nil, f); err != nil {
-			return nil, err
-		}
+		feats[i] = f
 		for _, v := range f.Scores() {
 			if math.IsNaN(v) {
 				continue
@@ -90,6 +97,9 @@ func NewScores(fs []Scorer, base ArcOfer, inner, outer vg.Length, renderer Score
 	if math.IsInf(max-min, 0) {
 		return nil, errors.New("rings: score range is infinite")
 	}
+	if _, _, err := visibleArcsOf(base, make([]Arc, 0, len(feats)), feats); err != nil {
+		return nil, err
+	}
 	return &Scores{
 		Set:      fs,
 		Base:     base,
@@ -108,25 +118,76 @@ func (r *Scores) DrawAt(ca draw.Canvas, cen vg.Point) {
 		return
 	}
 
-	r.Renderer.Configure(ca, cen, r.Base, r.Inner, r.Outer, r.Min, r.Max)
+	feats, min, max, err := r.renderable()
+	if err != nil {
+		panic(fmt.Sprint("rings: cannot bin scores:", err))
+	}
+	r.Renderer.Configure(ca, cen, r.Base, r.Inner, r.Outer, min, max)
+
+	arcs, feats, err := visibleArcsOf(r.Base, make([]Arc, 0, len(feats)), feats)
+	if err != nil {
+		panic(fmt.Sprint("rings: no arc for feature location:", err))
+	}
+	for i, f := range feats {
+		r.Renderer.Render(arcs[i], f.(Scorer))
+	}
+	r.Renderer.Close()
+}
+
+// renderable returns the features of the Set that are within the range of their
+// locations, binned according to Bins, and the score range to render them with.
+// The score range is the range of the binned scores if Bins is not nil, since
+// reduction may move scores outside the range of the unbinned scores, and Min
+// and Max otherwise.
+func (r *Scores) renderable() (feats []feat.Feature, min, max float64, err error) {
+	// Collect the renderable features so that their arcs
+	// can be found in a single pass.
+	var (
+		last   feat.Feature
+		lo, hi int
+	)
+	feats = make([]feat.Feature, 0, len(r.Set))
 	for _, f := range r.Set {
 		loc := f.Location()
-		min := loc.Start()
-		max := loc.End()
-
-		if f.Start() < min || f.End() > max {
-			continue
+		if loc != nil {
+			if loc != last {
+				last = loc
+				lo = loc.Start()
+				hi = loc.End()
+			}
+			if f.Start() < lo || f.End() > hi {
+				continue
+			}
 		}
+		feats = append(feats, f)
+	}
+	if r.Bins == nil {
+		return feats, r.Min, r.Max, nil
+	}
 
-		arc, err := r.Base.ArcOf(loc, f)
-		if err != nil {
-			panic(fmt.Sprint("rings: no arc for feature location:", err))
+	feats, err = r.Bins.bin(r.Base, feats)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, f := range feats {
+		for _, v := range f.(Scorer).Scores() {
+			if math.IsNaN(v) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
 		}
-		r.Renderer.Render(arc, f)
 	}
-	r.Renderer.Close()
+	if math.IsInf(max-min, 0) {
+		return feats, r.Min, r.Max, nil
+	}
+	return feats, min, max, nil
 }
 
+// Projection returns the projection of the Scores' Base.
+func (r *Scores) Projection() Projection { return projectionOf(r.Base) }
+
 // Plot calls DrawAt using the Scores' X and Y values as the drawing coordinates.
 func (r *Scores) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -136,12 +197,9 @@ func (r *Scores) Plot(ca draw.Canvas, plt *plot.Plot) {
 // GlyphBoxes returns a liberal glyphbox for the score rendering.
 func (r *Scores) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-r.Outer, -r.Outer},
-			Max: vg.Point{r.Outer, r.Outer},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(r.Outer),
 	}}
 }
 
@@ -161,21 +219,90 @@ func (as arcScores) Swap(i, j int)      { as[i], as[j] = as[j], as[i] }
 
 // Heat is a ScoreRenderer that represents feature scores as a color block.
 type Heat struct {
-	Palette   []color.Color
+	Palette []color.Color
+
+	// ColorMap is a continuous color map used in place of Palette
+	// if it is not nil. Transformed scores are mapped within the
+	// ColorMap's own range rather than between Min and Max. A
+	// Diverging may be used to centre a diverging color map on
+	// a chosen value.
+	ColorMap palette.ColorMap
+
 	Underflow color.Color
 	Overflow  color.Color
 
+	// Tracks describes the layout and color mapping of each score
+	// series. Series without a HeatTrack have a relative width of 1
+	// and use the Heat's color mapping. If Tracks is nil, all series
+	// have equal widths.
+	Tracks []HeatTrack
+
+	// Gap is the radial space between the blocks of adjacent
+	// score series.
+	Gap vg.Length
+
+	// LabelAngle is the angle at which the Labels of Tracks
+	// are rendered.
+	LabelAngle Angle
+
+	// LabelStyle is the style of the track label text. If its
+	// Color is nil, track labels are not rendered.
+	LabelStyle draw.TextStyle
+
+	// Placement determines the text rotation and alignment of
+	// track labels. If Placement is nil, DefaultPlacement is used.
+	Placement TextPlacement
+
 	DrawArea draw.Canvas
 
 	Center       vg.Point
 	Inner, Outer vg.Length
 
 	Min, Max float64
+
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to the palette. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each score value.
+	// If Rules is nil, no rules are applied.
+	Rules *Rules
+
+	proj Projection
+
+	// n is the largest number of score series rendered, and
+	// bounds holds the radial bounds of the blocks of each
+	// series in the most recent layout.
+	n      int
+	bounds [][2]vg.Length
+}
+
+// HeatTrack describes the rendering of a single score series of a Heat.
+type HeatTrack struct {
+	// Width is the radial width of the series' blocks relative
+	// to the widths of the other series. Non-positive widths
+	// are treated as a relative width of 1.
+	Width float64
+
+	// Palette and ColorMap specify the color mapping of the
+	// series. If both are nil, the Heat's color mapping is
+	// used. A non-nil ColorMap is used in place of Palette.
+	Palette  []color.Color
+	ColorMap palette.ColorMap
+
+	// Min and Max hold the score range of the series. If Min
+	// and Max are both zero, the Heat's score range is used.
+	Min, Max float64
+
+	// Label is the label of the series, usually a sample name.
+	Label string
 }
 
 // Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
 // the Heat's Min and Max fields are both non-zero.
-func (h *Heat) Configure(ca draw.Canvas, cen vg.Point, _ ArcOfer, inner, outer vg.Length, min, max float64) {
+func (h *Heat) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
+	h.proj = projectionOf(base)
 	h.DrawArea = ca
 	h.Center = cen
 	h.Inner = inner
@@ -184,58 +311,210 @@ func (h *Heat) Configure(ca draw.Canvas, cen vg.Point, _ ArcOfer, inner, outer v
 		h.Min = min
 		h.Max = max
 	}
+	h.n = 0
+	h.bounds = h.bounds[:0]
+}
+
+// layout returns the radial bounds of the blocks of n score series.
+func (h *Heat) layout(n int) [][2]vg.Length {
+	if len(h.bounds) == n {
+		return h.bounds
+	}
+	h.bounds = h.bounds[:0]
+	if n == 0 {
+		return h.bounds
+	}
+
+	avail := h.Outer - h.Inner - h.Gap*vg.Length(n-1)
+	width := func(j int) float64 {
+		if j < len(h.Tracks) && h.Tracks[j].Width > 0 {
+			return h.Tracks[j].Width
+		}
+		return 1
+	}
+	var total float64
+	if h.Tracks != nil {
+		for j := 0; j < n; j++ {
+			total += width(j)
+		}
+	}
+
+	// Define block progression inner to outer.
+	rad := h.Inner
+	for j := 0; j < n; j++ {
+		var d vg.Length
+		if h.Tracks == nil {
+			d = avail / vg.Length(n)
+		} else {
+			d = avail * vg.Length(width(j)/total)
+		}
+		h.bounds = append(h.bounds, [2]vg.Length{rad, rad + d})
+		rad += d + h.Gap
+	}
+	return h.bounds
 }
 
 // Render renders the values in scores across the specified arc from inner to outer.
 // Rendering is performed eagerly.
 func (h *Heat) Render(arc Arc, scorer Scorer) {
 	scores := scorer.Scores()
+	if len(scores) > h.n {
+		h.n = len(scores)
+	}
+	bounds := h.layout(len(scores))
 
-	ps := float64(len(h.Palette)-1) / (h.Max - h.Min)
-
-	// Define block progression inner to outer.
-	d := (h.Outer - h.Inner) / vg.Length(len(scores))
-	rad := h.Inner
+	defScale := newValueScale(h.Transform, h.Min, h.Max)
 
 	var pa vg.Path
-	for _, v := range scores {
+	for j, v := range scores {
 		pa = pa[:0]
 
-		pa.Move(h.Center.Add(Rectangular(arc.Theta, rad)))
-		pa.Arc(h.Center, rad, float64(arc.Theta), float64(arc.Phi))
-		rad += d
-		pa.Arc(h.Center, rad, float64(arc.Theta+arc.Phi), float64(-arc.Phi))
+		inner, outer := bounds[j][0], bounds[j][1]
+		pa.Move(h.Center.Add(h.proj.Point(arc.Theta, inner)))
+		h.proj.Arc(&pa, h.Center, inner, arc.Theta, arc.Phi)
+		h.proj.Arc(&pa, h.Center, outer, arc.Theta+arc.Phi, -arc.Phi)
 		pa.Close()
 
+		pal, cmap := h.Palette, h.ColorMap
+		min, max := h.Min, h.Max
+		scale := defScale
+		if j < len(h.Tracks) {
+			t := h.Tracks[j]
+			switch {
+			case t.ColorMap != nil:
+				cmap = t.ColorMap
+			case t.Palette != nil:
+				pal, cmap = t.Palette, nil
+			}
+			if t.Min != 0 || t.Max != 0 {
+				min, max = t.Min, t.Max
+				scale = newValueScale(h.Transform, min, max)
+			}
+		}
+
 		var c color.Color
 		switch {
 		case math.IsNaN(v), math.IsInf(v, 0):
-		case v < h.Min:
+		case cmap != nil:
+			var err error
+			c, err = cmap.At(scale.transform(v))
+			switch err {
+			case nil:
+			case palette.ErrUnderflow:
+				c = h.Underflow
+			case palette.ErrOverflow:
+				c = h.Overflow
+			default:
+				c = nil
+			}
+		case v < min:
 			c = h.Underflow
-		case v > h.Max:
+		case v > max:
 			c = h.Overflow
 		default:
-			c = h.Palette[int((v-h.Min)*ps+0.5)]
+			c = pal[int((scale.transform(v)-scale.lo)*scale.factor(float64(len(pal)-1))+0.5)]
 		}
-		if c != nil {
-			h.DrawArea.SetColor(c)
+		sty, visible := h.Rules.apply(Item{Feature: scorer, Index: j, Value: v}, Style{Fill: c})
+		if visible && sty.Fill != nil {
+			h.DrawArea.SetColor(sty.Fill)
 			h.DrawArea.Fill(pa)
 		}
 	}
 }
 
-// Close is a no-op.
-func (h *Heat) Close() {}
+// Close renders the labels of the Heat's Tracks at LabelAngle, centred on the radial
+// middle of each series' blocks.
+func (h *Heat) Close() {
+	if h.LabelStyle.Color == nil {
+		return
+	}
+	bounds := h.layout(h.n)
+	for j, t := range h.Tracks {
+		if j >= len(bounds) {
+			break
+		}
+		if t.Label == "" {
+			continue
+		}
+		var (
+			rot            Angle
+			xalign, yalign float64
+		)
+		if h.Placement == nil {
+			rot, xalign, yalign = DefaultPlacement(h.proj.Normal(h.LabelAngle))
+		} else {
+			rot, xalign, yalign = h.Placement(h.proj.Normal(h.LabelAngle))
+		}
+		sty := h.LabelStyle
+		sty.XAlign = draw.XAlignment(xalign)
+		sty.YAlign = draw.YAlignment(yalign)
+		sty.Rotation = float64(rot)
+		pt := h.Center.Add(h.proj.Point(h.LabelAngle, (bounds[j][0]+bounds[j][1])/2))
+		h.DrawArea.FillText(sty, pt, t.Label)
+	}
+}
+
+// scorers returns the Scorers of the held values.
+func (as arcScores) scorers() []Scorer {
+	set := make([]Scorer, len(as))
+	for i, v := range as {
+		set[i] = v.Scorer
+	}
+	return set
+}
+
+// radialScale returns the mapping of scores in the range min to max to radii, using
+// the transform tr and increasing toward the inner radius if inward is true.
+func radialScale(tr Transform, inward bool, min, max float64) valueScale {
+	scale := newValueScale(tr, min, max)
+	scale.inward = inward
+	return scale
+}
+
+// rendererRange returns the score range of a renderer with the range fields rmin and rmax
+// when it is configured with the score range min to max. The configured range is used
+// only if rmin and rmax are both zero.
+func rendererRange(rmin, rmax, min, max float64) (float64, float64) {
+	if rmin == 0 && rmax == 0 {
+		return min, max
+	}
+	return rmin, rmax
+}
 
 // Trace is a ScoreRenderer that represents feature scores as a trace line.
 type Trace struct {
 	// LineStyles determines the lines style for each trace.
 	LineStyles []draw.LineStyle
 
+	// Below determines the line style for each trace where scores are
+	// less than Baseline. Traces of series without a Below style use
+	// LineStyles for all scores.
+	Below []draw.LineStyle
+
+	// Baseline is the score value separating the trace styles of
+	// LineStyles and Below.
+	Baseline float64
+
+	// Smoother specifies the smoothing applied to each trace before
+	// it is rendered. Smoothing is performed independently for each
+	// feature location, using the positions of the centers of the
+	// features. If Smoother is nil, traces are not smoothed.
+	Smoother Smoother
+
+	// Raw determines the line style for each unsmoothed trace when
+	// Smoother is not nil. Unsmoothed traces are rendered beneath
+	// the smoothed traces. Unsmoothed traces of series without a
+	// Raw style are not rendered.
+	Raw []draw.LineStyle
+
 	// Join specifies whether adjacent features should be joined with radial lines.
 	// It is overridden by the returned value of JoinTrace if the Scorer is a TraceJoiner.
 	Join bool
 
+	// Inward specifies that scores increase toward the inner
+	// radius rather than toward the outer radius.
+	Inward bool
+
 	Base ArcOfer
 
 	DrawArea draw.Canvas
@@ -245,25 +524,45 @@ type Trace struct {
 
 	Min, Max float64
 
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to a radius. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each score value.
+	// If Rules is nil, no rules are applied.
+	Rules *Rules
+
 	// Axis represents a radial axis configuration
 	Axis *Axis
 
 	values arcScores
+	proj   Projection
 }
 
 // Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
-// the Trace's Min and Max fields are both non-zero.
+// the Trace's Min and Max fields are not both zero.
 func (t *Trace) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
 	t.values = t.values[:0]
+	t.proj = projectionOf(base)
 	t.DrawArea = ca
 	t.Center = cen
 	t.Base = base
 	t.Inner = inner
 	t.Outer = outer
-	if t.Max == 0 && t.Min == 0 {
-		t.Min = min
-		t.Max = max
-	}
+	t.Min, t.Max = rendererRange(t.Min, t.Max, min, max)
+}
+
+// Render add the scores at the specified arc for lazy rendering.
+func (t *Trace) Render(arc Arc, scorer Scorer) {
+	t.values = append(t.values, arcScore{arc, scorer})
+}
+
+// scoreMapping returns the transform, orientation and score range used to map scores
+// to radii when the Trace is configured with the score range min to max.
+func (t *Trace) scoreMapping(min, max float64) (Transform, bool, float64, float64) {
+	min, max = rendererRange(t.Min, t.Max, min, max)
+	return t.Transform, t.Inward, min, max
 }
 
 // TraceJoiner is a type that can specify whether the traces for its scores should
@@ -273,29 +572,42 @@ type TraceJoiner interface {
 	JoinTrace(i int) bool
 }
 
-// Render add the scores at the specified arc for lazy rendering.
-func (t *Trace) Render(arc Arc, scorer Scorer) {
-	t.values = append(t.values, arcScore{arc, scorer})
-}
-
 // Close renders the added scores and axis.
 func (t *Trace) Close() {
+	scale := radialScale(t.Transform, t.Inward, t.Min, t.Max)
 	if t.Axis != nil {
-		set := make([]Scorer, len(t.values))
-		for i, s := range t.values {
-			set[i] = s.Scorer
-		}
-		t.Axis.drawAt(t.DrawArea, t.Center, set, t.Base, t.Inner, t.Outer, t.Min, t.Max)
+		t.Axis.drawAt(t.DrawArea, t.Center, t.values.scorers(), t.Base, t.Inner, t.Outer, t.Min, t.Max, scale, t.Baseline)
 	}
 
 	sort.Sort(t.values)
 
-	rs := float64(t.Outer-t.Inner) / (t.Max - t.Min)
+	if t.Smoother == nil {
+		t.trace(scale, func(i int) []float64 { return t.values[i].Scores() }, t.LineStyles, t.Below)
+		return
+	}
+	smoothed := t.smooth()
+	if t.Raw != nil {
+		t.trace(scale, func(i int) []float64 { return t.values[i].Scores() }, t.Raw, nil)
+	}
+	t.trace(scale, func(i int) []float64 { return smoothed[i] }, t.LineStyles, t.Below)
+}
 
+// trace renders the sorted values with the scores for the ith value returned by scores
+// using the provided line styles for each series. Series without a line style are not
+// rendered.
+func (t *Trace) trace(scale valueScale, scores func(i int) []float64, styles, below []draw.LineStyle) {
 	var pa vg.Path
 	for i, arc := range t.values {
-		for j, as := range arc.Scores() {
-			if math.IsNaN(as) {
+		for j, as := range scores(i) {
+			if math.IsNaN(as) || j >= len(styles) {
+				continue
+			}
+			ls := styles[j]
+			if as < t.Baseline && j < len(below) {
+				ls = below[j]
+			}
+			sty, visible := t.Rules.apply(Item{Feature: arc.Scorer, Index: j, Value: as}, Style{Line: ls})
+			if !visible {
 				continue
 			}
 			pa = pa[:0]
@@ -311,35 +623,795 @@ func (t *Trace) Close() {
 				join = t.Join
 			}
 			if join && i != 0 && adjacent(t.values[i-1].Scorer, arc.Scorer) {
-				prev := t.values[i-1].Scores()[j]
+				prev := scores(i - 1)[j]
 				if !math.IsNaN(prev) && ((t.Min <= as && as <= t.Max) || (t.Min <= prev && prev <= t.Max)) {
 					joined = true
 
 					prev = math.Min(math.Max(prev, t.Min), t.Max)
 					as := math.Min(math.Max(as, t.Min), t.Max)
 
-					pa.Move(t.Center.Add(Rectangular(arc.Theta, vg.Length((prev-t.Min)*rs)+t.Inner)))
-					pa.Line(t.Center.Add(Rectangular(arc.Theta, vg.Length((as-t.Min)*rs)+t.Inner)))
+					pa.Move(t.Center.Add(t.proj.Point(arc.Theta, scale.radius(prev, t.Inner, t.Outer))))
+					pa.Line(t.Center.Add(t.proj.Point(arc.Theta, scale.radius(as, t.Inner, t.Outer))))
 				}
 			}
 
 			if t.Min <= as && as <= t.Max {
-				rad := vg.Length((as-t.Min)*rs) + t.Inner
+				rad := scale.radius(as, t.Inner, t.Outer)
 				if !joined {
-					pa.Move(t.Center.Add(Rectangular(arc.Theta, rad)))
+					pa.Move(t.Center.Add(t.proj.Point(arc.Theta, rad)))
 				}
-				pa.Arc(t.Center, rad, float64(arc.Theta), float64(arc.Phi))
+				t.proj.Arc(&pa, t.Center, rad, arc.Theta, arc.Phi)
 			}
 
-			sty := t.LineStyles[j]
-			if sty.Color != nil && sty.Width != 0 {
-				t.DrawArea.SetLineStyle(sty)
+			if sty.Line.Color != nil && sty.Line.Width != 0 {
+				t.DrawArea.SetLineStyle(sty.Line)
 				t.DrawArea.Stroke(pa)
 			}
 		}
 	}
 }
 
+// runPosition is the position of the ith sorted value of a Trace.
+type runPosition struct {
+	i   int
+	pos float64
+}
+
+// runPositions sorts runPosition values by position.
+type runPositions []runPosition
+
+func (p runPositions) Len() int           { return len(p) }
+func (p runPositions) Less(i, j int) bool { return p[i].pos < p[j].pos }
+func (p runPositions) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// smooth returns the scores of the sorted values smoothed by the Trace's Smoother.
+// Each run of values on the same feature location is smoothed independently.
+func (t *Trace) smooth() [][]float64 {
+	smoothed := make([][]float64, len(t.values))
+	var (
+		run            runPositions
+		pos, vals, dst []float64
+	)
+	for lo := 0; lo < len(t.values); {
+		loc := t.values[lo].Location()
+		run = run[:0]
+		var n int
+		hi := lo
+		for ; hi < len(t.values) && t.values[hi].Location() == loc; hi++ {
+			v := t.values[hi]
+			l := len(v.Scores())
+			if l > n {
+				n = l
+			}
+			smoothed[hi] = make([]float64, l)
+			run = append(run, runPosition{i: hi, pos: float64(v.Start()+v.End()) / 2})
+		}
+		sort.Stable(run)
+
+		pos = pos[:0]
+		for _, p := range run {
+			pos = append(pos, p.pos)
+		}
+		for j := 0; j < n; j++ {
+			vals = vals[:0]
+			for _, p := range run {
+				v := math.NaN()
+				if s := t.values[p.i].Scores(); j < len(s) {
+					v = s[j]
+				}
+				vals = append(vals, v)
+			}
+			dst = append(dst[:0], vals...)
+			t.Smoother.Smooth(dst, pos, vals)
+			for k, p := range run {
+				if j < len(smoothed[p.i]) {
+					smoothed[p.i][j] = dst[k]
+				}
+			}
+		}
+		lo = hi
+	}
+	return smoothed
+}
+
+// Histogram is a ScoreRenderer that represents feature scores as histogram bars
+// rising from a baseline.
+type Histogram struct {
+	// Colors determines the fill color of the bars for each score series.
+	// Bars of series without a non-nil color are not filled.
+	Colors []color.Color
+
+	// Below determines the fill color of bars for scores less than
+	// Baseline for each score series. Bars of series without a Below
+	// color are filled according to Colors.
+	Below []color.Color
+
+	// LineStyles determines the outline style of the bars for each score series.
+	LineStyles []draw.LineStyle
+
+	// Baseline is the score value that bars rise from. Bars for scores
+	// less than Baseline extend from the baseline in the opposite direction.
+	Baseline float64
+
+	// Join specifies whether adjacent bars should be joined into a continuous skyline.
+	// It is overridden by the returned value of JoinTrace if the Scorer is a TraceJoiner.
+	Join bool
+
+	// Inward specifies that scores increase toward the inner
+	// radius rather than toward the outer radius.
+	Inward bool
+
+	Base ArcOfer
+
+	DrawArea draw.Canvas
+
+	Center       vg.Point
+	Inner, Outer vg.Length
+
+	Min, Max float64
+
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to a radius. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each score value.
+	// If Rules is nil, no rules are applied.
+	Rules *Rules
+
+	// Axis represents a radial axis configuration
+	Axis *Axis
+
+	values arcScores
+	proj   Projection
+}
+
+// Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
+// the Histogram's Min and Max fields are not both zero.
+func (h *Histogram) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
+	h.values = h.values[:0]
+	h.proj = projectionOf(base)
+	h.DrawArea = ca
+	h.Center = cen
+	h.Base = base
+	h.Inner = inner
+	h.Outer = outer
+	h.Min, h.Max = rendererRange(h.Min, h.Max, min, max)
+}
+
+// Render add the scores at the specified arc for lazy rendering.
+func (h *Histogram) Render(arc Arc, scorer Scorer) {
+	h.values = append(h.values, arcScore{arc, scorer})
+}
+
+// scoreMapping returns the transform, orientation and score range used to map scores
+// to radii when the Histogram is configured with the score range min to max.
+func (h *Histogram) scoreMapping(min, max float64) (Transform, bool, float64, float64) {
+	min, max = rendererRange(h.Min, h.Max, min, max)
+	return h.Transform, h.Inward, min, max
+}
+
+// Close renders the added scores and axis. Scores and the baseline are clamped
+// to the range between Min and Max, and NaN and infinite scores are not rendered.
+func (h *Histogram) Close() {
+	scale := radialScale(h.Transform, h.Inward, h.Min, h.Max)
+	if h.Axis != nil {
+		h.Axis.drawAt(h.DrawArea, h.Center, h.values.scorers(), h.Base, h.Inner, h.Outer, h.Min, h.Max, scale, h.Baseline)
+	}
+
+	sort.Sort(h.values)
+
+	var n int
+	for _, v := range h.values {
+		if l := len(v.Scores()); l > n {
+			n = l
+		}
+	}
+
+	radius := func(v float64) vg.Length {
+		return scale.radius(math.Min(math.Max(v, h.Min), h.Max), h.Inner, h.Outer)
+	}
+	base := radius(h.Baseline)
+
+	var pa vg.Path
+	for j := 0; j < n; j++ {
+		var def Style
+		if j < len(h.Colors) {
+			def.Fill = h.Colors[j]
+		}
+		if j < len(h.LineStyles) {
+			def.Line = h.LineStyles[j]
+		}
+
+		var (
+			open       bool
+			start, end Angle
+			run        Style
+		)
+		// flush closes the current bar or skyline along
+		// the baseline and renders it.
+		flush := func() {
+			if !open {
+				return
+			}
+			open = false
+			pa.Line(h.Center.Add(h.proj.Point(end, base)))
+			h.proj.Arc(&pa, h.Center, base, end, start-end)
+			pa.Close()
+
+			if run.Fill != nil {
+				h.DrawArea.SetColor(run.Fill)
+				h.DrawArea.Fill(pa)
+			}
+			if run.Line.Color != nil && run.Line.Width != 0 {
+				h.DrawArea.SetLineStyle(run.Line)
+				h.DrawArea.Stroke(pa)
+			}
+		}
+
+		for i, v := range h.values {
+			arc := v.Arc
+			if arc.Phi < 0 {
+				arc.Theta, arc.Phi = arc.Theta+arc.Phi, -arc.Phi
+			}
+			scores := v.Scores()
+			if j >= len(scores) || math.IsNaN(scores[j]) || math.IsInf(scores[j], 0) {
+				flush()
+				continue
+			}
+			sty := def
+			if scores[j] < h.Baseline && j < len(h.Below) {
+				sty.Fill = h.Below[j]
+			}
+			sty, visible := h.Rules.apply(Item{Feature: v.Scorer, Index: j, Value: scores[j]}, sty)
+			if !visible {
+				flush()
+				continue
+			}
+
+			var join bool
+			if tj, ok := v.Scorer.(TraceJoiner); ok {
+				join = tj.JoinTrace(j)
+			} else {
+				join = h.Join
+			}
+			// A bar is open only if the previous value was rendered.
+			// Skylines are broken where the style changes.
+			if !open || !join || !adjacent(h.values[i-1].Scorer, v.Scorer) || !sameColor(sty.Fill, run.Fill) || !sameLineStyle(sty.Line, run.Line) {
+				flush()
+				pa = pa[:0]
+				start = arc.Theta
+				pa.Move(h.Center.Add(h.proj.Point(start, base)))
+				open = true
+				run = sty
+			}
+			rad := radius(scores[j])
+			pa.Line(h.Center.Add(h.proj.Point(arc.Theta, rad)))
+			h.proj.Arc(&pa, h.Center, rad, arc.Theta, arc.Phi)
+			end = arc.Theta + arc.Phi
+		}
+		flush()
+	}
+}
+
+// Scatter is a ScoreRenderer that represents feature scores as glyphs placed at the
+// middle of each feature's arc.
+type Scatter struct {
+	// GlyphStyles determines the glyph style for each score series. It is overridden
+	// by the returned value of GlyphStyle if the Scorer is a GlyphStyler.
+	GlyphStyles []draw.GlyphStyle
+
+	// Inward specifies that scores increase toward the inner
+	// radius rather than toward the outer radius.
+	Inward bool
+
+	Base ArcOfer
+
+	DrawArea draw.Canvas
+
+	Center       vg.Point
+	Inner, Outer vg.Length
+
+	Min, Max float64
+
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to a radius. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each score value.
+	// If Rules is nil, no rules are applied.
+	Rules *Rules
+
+	// Axis represents a radial axis configuration
+	Axis *Axis
+
+	values arcScores
+	proj   Projection
+}
+
+// Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
+// the Scatter's Min and Max fields are not both zero.
+func (s *Scatter) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
+	s.values = s.values[:0]
+	s.proj = projectionOf(base)
+	s.DrawArea = ca
+	s.Center = cen
+	s.Base = base
+	s.Inner = inner
+	s.Outer = outer
+	s.Min, s.Max = rendererRange(s.Min, s.Max, min, max)
+}
+
+// Render add the scores at the specified arc for lazy rendering.
+func (s *Scatter) Render(arc Arc, scorer Scorer) {
+	s.values = append(s.values, arcScore{arc, scorer})
+}
+
+// scoreMapping returns the transform, orientation and score range used to map scores
+// to radii when the Scatter is configured with the score range min to max.
+func (s *Scatter) scoreMapping(min, max float64) (Transform, bool, float64, float64) {
+	min, max = rendererRange(s.Min, s.Max, min, max)
+	return s.Transform, s.Inward, min, max
+}
+
+// Close renders the added scores and axis. Scores outside the range between
+// Min and Max are not rendered.
+func (s *Scatter) Close() {
+	scale := radialScale(s.Transform, s.Inward, s.Min, s.Max)
+	if s.Axis != nil {
+		s.Axis.drawAt(s.DrawArea, s.Center, s.values.scorers(), s.Base, s.Inner, s.Outer, s.Min, s.Max, scale, math.NaN())
+	}
+
+	for _, v := range s.values {
+		theta := v.Theta + v.Phi/2
+		gs, isStyler := v.Scorer.(GlyphStyler)
+		for j, as := range v.Scores() {
+			if math.IsNaN(as) || as < s.Min || s.Max < as {
+				continue
+			}
+
+			var sty draw.GlyphStyle
+			switch {
+			case isStyler:
+				sty = gs.GlyphStyle(j)
+			case j < len(s.GlyphStyles):
+				sty = s.GlyphStyles[j]
+			}
+			style, visible := s.Rules.apply(Item{Feature: v.Scorer, Index: j, Value: as}, Style{Glyph: sty})
+			sty = style.Glyph
+			if !visible || sty.Color == nil || sty.Shape == nil || sty.Radius == 0 {
+				continue
+			}
+
+			rad := scale.radius(as, s.Inner, s.Outer)
+			s.DrawArea.DrawGlyph(sty, s.Center.Add(s.proj.Point(theta, rad)))
+		}
+	}
+}
+
+// Area is a ScoreRenderer that represents feature scores as a trace line with the
+// region between the trace and a baseline filled.
+type Area struct {
+	// Above and Below determine the fill colors for each score series of the
+	// regions above and below the series' baseline. Regions of series without
+	// a non-nil color are not filled.
+	Above, Below []color.Color
+
+	// LineStyles determines the lines style for each trace.
+	LineStyles []draw.LineStyle
+
+	// Baseline is the score value that filled regions extend from.
+	Baseline float64
+
+	// Stack specifies whether score series should be stacked, with each
+	// trace being the sum of the scores of the series up to and including
+	// it. The filled region of each series extends from the trace of the
+	// preceding series, or from Baseline for the first series.
+	Stack bool
+
+	// Join specifies whether adjacent features should be joined with radial lines.
+	// It is overridden by the returned value of JoinTrace if the Scorer is a TraceJoiner.
+	Join bool
+
+	// Inward specifies that scores increase toward the inner
+	// radius rather than toward the outer radius.
+	Inward bool
+
+	Base ArcOfer
+
+	DrawArea draw.Canvas
+
+	Center       vg.Point
+	Inner, Outer vg.Length
+
+	Min, Max float64
+
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to a radius. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each score value.
+	// If Rules is nil, no rules are applied.
+	Rules *Rules
+
+	// Axis represents a radial axis configuration
+	Axis *Axis
+
+	values arcScores
+	proj   Projection
+}
+
+// Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
+// the Area's Min and Max fields are not both zero.
+func (a *Area) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
+	a.values = a.values[:0]
+	a.proj = projectionOf(base)
+	a.DrawArea = ca
+	a.Center = cen
+	a.Base = base
+	a.Inner = inner
+	a.Outer = outer
+	a.Min, a.Max = rendererRange(a.Min, a.Max, min, max)
+}
+
+// Render add the scores at the specified arc for lazy rendering.
+func (a *Area) Render(arc Arc, scorer Scorer) {
+	a.values = append(a.values, arcScore{arc, scorer})
+}
+
+// scoreMapping returns the transform, orientation and score range used to map scores
+// to radii when the Area is configured with the score range min to max.
+func (a *Area) scoreMapping(min, max float64) (Transform, bool, float64, float64) {
+	min, max = rendererRange(a.Min, a.Max, min, max)
+	return a.Transform, a.Inward, min, max
+}
+
+// areaSpan is a rendered region of an Area bounded by the radii lo and hi.
+type areaSpan struct {
+	Arc
+	lo, hi vg.Length
+}
+
+// Close renders the added scores and axis. Traces and baselines are clamped to the range
+// between Min and Max, and NaN scores are not rendered. NaN scores do not contribute to
+// the baseline of the following series when stacking, but scores hidden by Rules do.
+func (a *Area) Close() {
+	scale := radialScale(a.Transform, a.Inward, a.Min, a.Max)
+	if a.Axis != nil {
+		a.Axis.drawAt(a.DrawArea, a.Center, a.values.scorers(), a.Base, a.Inner, a.Outer, a.Min, a.Max, scale, a.Baseline)
+	}
+
+	sort.Sort(a.values)
+
+	var n int
+	for _, v := range a.values {
+		if l := len(v.Scores()); l > n {
+			n = l
+		}
+	}
+
+	radius := func(v float64) vg.Length {
+		return scale.radius(math.Min(math.Max(v, a.Min), a.Max), a.Inner, a.Outer)
+	}
+
+	// sum holds the stacked total at each feature, or NaN
+	// if no series has yet been stacked there.
+	sum := make([]float64, len(a.values))
+	for i := range sum {
+		sum[i] = math.NaN()
+	}
+
+	var (
+		spans []areaSpan
+		pa    vg.Path
+	)
+	for j := 0; j < n; j++ {
+		var (
+			filled int
+			run    Style
+		)
+		// fill renders the filled region of the current spans
+		// that have not yet been filled.
+		fill := func() {
+			todo := spans[filled:]
+			filled = len(spans)
+			if len(todo) == 0 {
+				return
+			}
+			if run.Fill == nil {
+				return
+			}
+			pa = pa[:0]
+			pa.Move(a.Center.Add(a.proj.Point(todo[0].Theta, todo[0].lo)))
+			for _, s := range todo {
+				pa.Line(a.Center.Add(a.proj.Point(s.Theta, s.hi)))
+				a.proj.Arc(&pa, a.Center, s.hi, s.Theta, s.Phi)
+			}
+			for k := len(todo) - 1; k >= 0; k-- {
+				s := todo[k]
+				pa.Line(a.Center.Add(a.proj.Point(s.Theta+s.Phi, s.lo)))
+				a.proj.Arc(&pa, a.Center, s.lo, s.Theta+s.Phi, -s.Phi)
+			}
+			pa.Close()
+			a.DrawArea.SetColor(run.Fill)
+			a.DrawArea.Fill(pa)
+		}
+		// flush renders the current joined run of spans.
+		flush := func() {
+			fill()
+			if len(spans) != 0 {
+				if sty := run.Line; sty.Color != nil && sty.Width != 0 {
+					pa = pa[:0]
+					pa.Move(a.Center.Add(a.proj.Point(spans[0].Theta, spans[0].hi)))
+					for k, s := range spans {
+						if k != 0 {
+							pa.Line(a.Center.Add(a.proj.Point(s.Theta, s.hi)))
+						}
+						a.proj.Arc(&pa, a.Center, s.hi, s.Theta, s.Phi)
+					}
+					a.DrawArea.SetLineStyle(sty)
+					a.DrawArea.Stroke(pa)
+				}
+			}
+			spans = spans[:0]
+			filled = 0
+		}
+
+		for i, v := range a.values {
+			arc := v.Arc
+			if arc.Phi < 0 {
+				arc.Theta, arc.Phi = arc.Theta+arc.Phi, -arc.Phi
+			}
+			scores := v.Scores()
+			if j >= len(scores) || math.IsNaN(scores[j]) {
+				flush()
+				continue
+			}
+
+			lo, hi := a.Baseline, scores[j]
+			if a.Stack {
+				if !math.IsNaN(sum[i]) {
+					lo = sum[i]
+					hi += lo
+				}
+				sum[i] = hi
+			}
+
+			var sty Style
+			if hi >= lo {
+				if j < len(a.Above) {
+					sty.Fill = a.Above[j]
+				}
+			} else if j < len(a.Below) {
+				sty.Fill = a.Below[j]
+			}
+			if j < len(a.LineStyles) {
+				sty.Line = a.LineStyles[j]
+			}
+			sty, visible := a.Rules.apply(Item{Feature: v.Scorer, Index: j, Value: scores[j]}, sty)
+			if !visible {
+				flush()
+				continue
+			}
+
+			var join bool
+			if tj, ok := v.Scorer.(TraceJoiner); ok {
+				join = tj.JoinTrace(j)
+			} else {
+				join = a.Join
+			}
+			// Spans are held only if the previous value was rendered.
+			// Traces are broken where the line style changes and filled
+			// regions are broken where the fill color changes.
+			if len(spans) == 0 || !join || !adjacent(a.values[i-1].Scorer, v.Scorer) || !sameLineStyle(sty.Line, run.Line) {
+				flush()
+			} else if !sameColor(sty.Fill, run.Fill) {
+				fill()
+			}
+			run = sty
+			spans = append(spans, areaSpan{Arc: arc, lo: radius(lo), hi: radius(hi)})
+		}
+		flush()
+	}
+}
+
+// Band is a ScoreRenderer that represents a series of feature scores as a center line
+// within a filled envelope bounded by two other score series, such as a mean and its
+// confidence interval.
+type Band struct {
+	// Middle, Lower and Upper are the indices of the score series
+	// holding the center line and the lower and upper bounds of
+	// the envelope.
+	Middle, Lower, Upper int
+
+	// Fill is the fill color of the envelope. Fill is usually
+	// translucent. If Fill is nil, the envelope is not filled.
+	Fill color.Color
+
+	// LineStyle is the style of the center line.
+	LineStyle draw.LineStyle
+
+	// BoundStyle is the style of the lines bounding the envelope.
+	BoundStyle draw.LineStyle
+
+	// Join specifies whether adjacent features should be joined with radial lines.
+	// It is overridden by the returned value of JoinTrace for the Middle series if
+	// the Scorer is a TraceJoiner.
+	Join bool
+
+	// Inward specifies that scores increase toward the inner
+	// radius rather than toward the outer radius.
+	Inward bool
+
+	Base ArcOfer
+
+	DrawArea draw.Canvas
+
+	Center       vg.Point
+	Inner, Outer vg.Length
+
+	Min, Max float64
+
+	// Transform specifies the transformation applied to scores
+	// before they are mapped to a radius. If Transform is nil,
+	// scores are mapped linearly.
+	Transform Transform
+
+	// Rules specifies styling rules applied to each center line
+	// score value. Rules alter the Fill and Line fields of the
+	// style of the envelope and center line. If Rules is nil, no
+	// rules are applied.
+	Rules *Rules
+
+	// Axis represents a radial axis configuration
+	Axis *Axis
+
+	values arcScores
+	proj   Projection
+}
+
+// Configure is called by Scores' DrawAt method. The min and max parameters are ignored if
+// the Band's Min and Max fields are not both zero.
+func (b *Band) Configure(ca draw.Canvas, cen vg.Point, base ArcOfer, inner, outer vg.Length, min, max float64) {
+	b.values = b.values[:0]
+	b.proj = projectionOf(base)
+	b.DrawArea = ca
+	b.Center = cen
+	b.Base = base
+	b.Inner = inner
+	b.Outer = outer
+	b.Min, b.Max = rendererRange(b.Min, b.Max, min, max)
+}
+
+// Render add the scores at the specified arc for lazy rendering.
+func (b *Band) Render(arc Arc, scorer Scorer) {
+	b.values = append(b.values, arcScore{arc, scorer})
+}
+
+// scoreMapping returns the transform, orientation and score range used to map scores
+// to radii when the Band is configured with the score range min to max.
+func (b *Band) scoreMapping(min, max float64) (Transform, bool, float64, float64) {
+	min, max = rendererRange(b.Min, b.Max, min, max)
+	return b.Transform, b.Inward, min, max
+}
+
+// bandSpan is a rendered segment of a Band with the center line at
+// radius mid and the envelope bounded by the radii lo and hi.
+type bandSpan struct {
+	Arc
+	lo, mid, hi vg.Length
+}
+
+// Close renders the added scores and axis. Scores are clamped to the range between
+// Min and Max. Features with a NaN value or no value in any of the Middle, Lower and
+// Upper series are not rendered.
+func (b *Band) Close() {
+	scale := radialScale(b.Transform, b.Inward, b.Min, b.Max)
+	if b.Axis != nil {
+		b.Axis.drawAt(b.DrawArea, b.Center, b.values.scorers(), b.Base, b.Inner, b.Outer, b.Min, b.Max, scale, math.NaN())
+	}
+
+	sort.Sort(b.values)
+
+	radius := func(v float64) vg.Length {
+		return scale.radius(math.Min(math.Max(v, b.Min), b.Max), b.Inner, b.Outer)
+	}
+	score := func(scores []float64, j int) float64 {
+		if j < 0 || j >= len(scores) {
+			return math.NaN()
+		}
+		return scores[j]
+	}
+
+	var (
+		spans []bandSpan
+		run   Style
+		pa    vg.Path
+	)
+	// line fills pa with the joined trace through the radii
+	// of the current spans returned by r.
+	line := func(r func(bandSpan) vg.Length) {
+		pa = pa[:0]
+		pa.Move(b.Center.Add(b.proj.Point(spans[0].Theta, r(spans[0]))))
+		for k, s := range spans {
+			if k != 0 {
+				pa.Line(b.Center.Add(b.proj.Point(s.Theta, r(s))))
+			}
+			b.proj.Arc(&pa, b.Center, r(s), s.Theta, s.Phi)
+		}
+	}
+	// flush renders the current joined run of spans.
+	flush := func() {
+		if len(spans) == 0 {
+			return
+		}
+		if run.Fill != nil {
+			pa = pa[:0]
+			pa.Move(b.Center.Add(b.proj.Point(spans[0].Theta, spans[0].lo)))
+			for _, s := range spans {
+				pa.Line(b.Center.Add(b.proj.Point(s.Theta, s.hi)))
+				b.proj.Arc(&pa, b.Center, s.hi, s.Theta, s.Phi)
+			}
+			for k := len(spans) - 1; k >= 0; k-- {
+				s := spans[k]
+				pa.Line(b.Center.Add(b.proj.Point(s.Theta+s.Phi, s.lo)))
+				b.proj.Arc(&pa, b.Center, s.lo, s.Theta+s.Phi, -s.Phi)
+			}
+			pa.Close()
+			b.DrawArea.SetColor(run.Fill)
+			b.DrawArea.Fill(pa)
+		}
+		if sty := b.BoundStyle; sty.Color != nil && sty.Width != 0 {
+			b.DrawArea.SetLineStyle(sty)
+			line(func(s bandSpan) vg.Length { return s.lo })
+			b.DrawArea.Stroke(pa)
+			line(func(s bandSpan) vg.Length { return s.hi })
+			b.DrawArea.Stroke(pa)
+		}
+		if sty := run.Line; sty.Color != nil && sty.Width != 0 {
+			line(func(s bandSpan) vg.Length { return s.mid })
+			b.DrawArea.SetLineStyle(sty)
+			b.DrawArea.Stroke(pa)
+		}
+		spans = spans[:0]
+	}
+
+	for i, v := range b.values {
+		arc := v.Arc
+		if arc.Phi < 0 {
+			arc.Theta, arc.Phi = arc.Theta+arc.Phi, -arc.Phi
+		}
+		scores := v.Scores()
+		mid, lo, hi := score(scores, b.Middle), score(scores, b.Lower), score(scores, b.Upper)
+		if math.IsNaN(mid) || math.IsNaN(lo) || math.IsNaN(hi) {
+			flush()
+			continue
+		}
+		sty, visible := b.Rules.apply(Item{Feature: v.Scorer, Index: b.Middle, Value: mid}, Style{Fill: b.Fill, Line: b.LineStyle})
+		if !visible {
+			flush()
+			continue
+		}
+
+		var join bool
+		if tj, ok := v.Scorer.(TraceJoiner); ok {
+			join = tj.JoinTrace(b.Middle)
+		} else {
+			join = b.Join
+		}
+		// Spans are held only if the previous value was rendered.
+		// Bands are broken where the style changes.
+		if len(spans) == 0 || !join || !adjacent(b.values[i-1].Scorer, v.Scorer) || !sameColor(sty.Fill, run.Fill) || !sameLineStyle(sty.Line, run.Line) {
+			flush()
+		}
+		run = sty
+		spans = append(spans, bandSpan{Arc: arc, lo: radius(lo), mid: radius(mid), hi: radius(hi)})
+	}
+	flush()
+}
+
 func adjacent(a, b feat.Feature) bool {
 	return a.Location() == b.Location() && a.Start() == b.End() || b.Start() == a.End()
 }
@@ -0,0 +1,448 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// Segment describes the mapping of a region of a feature to an arc. The
+// position Start is mapped to Theta and the position End is mapped to
+// Theta+Phi, with positions in between mapped linearly.
+type Segment struct {
+	Start, End int
+	Arc
+}
+
+// angleAt returns the angle of position pos within the segment.
+func (s Segment) angleAt(pos int) Angle {
+	if s.End == s.Start {
+		return s.Theta
+	}
+	return s.Theta + s.Phi*Angle(pos-s.Start)/Angle(s.End-s.Start)
+}
+
+// SegmentedArcs is an ArcOfer that maps regions of features to arcs of a base arc.
+// Unlike Arcs, the mapping from a feature's coordinates to angles may be non-uniform.
+type SegmentedArcs struct {
+	Base Arc // Base represents the complete span of the SegmentedArcs.
+
+	// Segments provides a lookup for features within the span. The segments
+	// for each feature must be sorted by Start and must not overlap.
+	Segments map[feat.Feature][]Segment
+}
+
+// ErrOutsideWindows is the error returned by SegmentedArcs.ArcOf and ArcsOf when a query
+// feature lies outside all the segments of its location. Features that partially overlap
+// the segments of their location are clipped to the segments rather than reported.
+var ErrOutsideWindows = errors.New("rings: feature outside display windows")
+
+// Zoom describes an angular scale factor for a feature or a region of a feature.
+type Zoom struct {
+	// Feature is the feature holding the zoomed region.
+	Feature feat.Feature
+
+	// Start and End specify the zoomed region in the coordinates
+	// of Feature. If both Start and End are zero, the complete
+	// feature is zoomed.
+	Start, End int
+
+	// Scale is the angular sweep per position of the region relative
+	// to unzoomed regions. Scale must be positive.
+	Scale float64
+}
+
+// NewZoomedArcs returns a SegmentedArcs that maps the provided features to the base arc with
+// a fractional gap between each feature. The angular sweep of each feature is proportional to
+// its length, except within the regions specified by zooms, where the sweep is multiplied by
+// the zoom's Scale. The total sweep of the features and gaps fills the base arc.
+//
+// An error is returned if a zoom refers to a feature not in fs, has a non-positive scale,
+// is out of the range of its feature or overlaps another zoom.
+func NewZoomedArcs(base Arcer, fs []feat.Feature, gap float64, zooms []Zoom) (SegmentedArcs, error) {
+	index := make(map[feat.Feature]int, len(fs))
+	for i, f := range fs {
+		index[f] = i
+	}
+	zoomsOf := make([][]Zoom, len(fs))
+	for _, z := range zooms {
+		i, ok := index[z.Feature]
+		if !ok {
+			return SegmentedArcs{}, errors.New("rings: zoom feature not found")
+		}
+		if !(z.Scale > 0) {
+			return SegmentedArcs{}, errors.New("rings: zoom scale not positive")
+		}
+		if z.Start == 0 && z.End == 0 {
+			z.Start, z.End = z.Feature.Start(), z.Feature.End()
+		}
+		if z.End < z.Start {
+			return SegmentedArcs{}, errors.New("rings: inverted zoom")
+		}
+		if z.Start < z.Feature.Start() || z.End > z.Feature.End() {
+			return SegmentedArcs{}, errors.New("rings: zoom out of range")
+		}
+		zoomsOf[i] = append(zoomsOf[i], z)
+	}
+
+	// Partition each feature into regions of constant scale.
+	type region struct {
+		start, end int
+		scale      float64
+	}
+	regions := make([][]region, len(fs))
+	var total float64
+	for i, f := range fs {
+		zs := zoomsOf[i]
+		sort.Sort(zoomsByStart(zs))
+		pos := f.Start()
+		for j, z := range zs {
+			if j != 0 && z.Start < zs[j-1].End {
+				return SegmentedArcs{}, errors.New("rings: overlapping zooms")
+			}
+			if z.Start > pos {
+				regions[i] = append(regions[i], region{start: pos, end: z.Start, scale: 1})
+			}
+			regions[i] = append(regions[i], region{start: z.Start, end: z.End, scale: z.Scale})
+			pos = z.End
+		}
+		if pos < f.End() || len(regions[i]) == 0 {
+			regions[i] = append(regions[i], region{start: pos, end: f.End(), scale: 1})
+		}
+		for _, r := range regions[i] {
+			total += float64(r.end-r.start) * r.scale
+		}
+	}
+
+	arc := base.Arc()
+	scale := arc.Phi * Angle((1-gap*float64(len(fs)))/total)
+	g := Angle(gap) * arc.Phi
+
+	segs := make(map[feat.Feature][]Segment, len(fs))
+	theta := arc.Theta + g/2
+	for i, f := range fs {
+		var phi Angle
+		for _, r := range regions[i] {
+			phi += Angle(float64(r.end-r.start)*r.scale) * scale
+		}
+
+		reverse := false
+		if fo, ok := f.(featureOrienter); ok && globalOrientation(fo) == feat.Reverse {
+			reverse = true
+		}
+
+		s := make([]Segment, len(regions[i]))
+		var off Angle
+		for j, r := range regions[i] {
+			sweep := Angle(float64(r.end-r.start)*r.scale) * scale
+			if reverse {
+				s[j] = Segment{Start: r.start, End: r.end, Arc: Arc{Theta: Normalize(theta+phi) - off, Phi: -sweep}}
+			} else {
+				s[j] = Segment{Start: r.start, End: r.end, Arc: Arc{Theta: Normalize(theta) + off, Phi: sweep}}
+			}
+			off += sweep
+		}
+		segs[f] = s
+		theta += phi + g
+	}
+
+	return SegmentedArcs{Base: arc, Segments: segs}, nil
+}
+
+// zoomsByStart sorts a []Zoom by ascending start position.
+type zoomsByStart []Zoom
+
+func (z zoomsByStart) Len() int           { return len(z) }
+func (z zoomsByStart) Less(i, j int) bool { return z[i].Start < z[j].Start }
+func (z zoomsByStart) Swap(i, j int)      { z[i], z[j] = z[j], z[i] }
+
+// Window is a feat.Feature describing a display window on a region of a feature.
+type Window struct {
+	// Loc is the feature holding the displayed region.
+	Loc feat.Feature
+
+	// From and To specify the displayed region in the coordinates of Loc.
+	From, To int
+
+	// Orient specifies the orientation of the displayed region. Windows
+	// with feat.Reverse orientation are displayed reversed.
+	Orient feat.Orientation
+}
+
+// Start returns the start position of the displayed region.
+func (w *Window) Start() int { return w.From }
+
+// End returns the end position of the displayed region.
+func (w *Window) End() int { return w.To }
+
+// Len returns the length of the displayed region.
+func (w *Window) Len() int { return w.To - w.From }
+
+// Name returns the name of the window in the form "location:start-end".
+func (w *Window) Name() string { return fmt.Sprintf("%s:%d-%d", w.Loc.Name(), w.From, w.To) }
+
+// Description returns the description of the window.
+func (w *Window) Description() string { return "display window" }
+
+// Location returns the feature holding the displayed region.
+func (w *Window) Location() feat.Feature { return w.Loc }
+
+// Orientation returns the orientation of the displayed region.
+func (w *Window) Orientation() feat.Orientation { return w.Orient }
+
+// NewWindowedArcs returns a SegmentedArcs that maps the provided display windows to the base arc
+// with a fractional gap between each window. Each window is a feature describing a region of its
+// location and windows are placed in the order they are provided, so a single location may be
+// cropped, split or reordered. Windows that are feat.Orienters with feat.Reverse orientation are
+// displayed reversed.
+//
+// Features are mapped by the returned SegmentedArcs from the coordinates of the windows' locations,
+// so the features of the original locations do not need to be re-parented. Features that fall
+// outside every window are reported by ArcOf with the error ErrOutsideWindows. Features that
+// partially overlap a window are clipped to the window, so the arc returned for them spans only
+// the displayed part of the feature. The windows themselves are also mapped, so they may be
+// used to label or draw the displayed regions.
+//
+// An error is returned if a window has no location, is inverted, is out of the range of
+// its location or overlaps another window on the same location.
+func NewWindowedArcs(base Arcer, ws []feat.Feature, gap float64) (SegmentedArcs, error) {
+	var total float64
+	for _, w := range ws {
+		loc := w.Location()
+		if loc == nil {
+			return SegmentedArcs{}, errors.New("rings: window has no location")
+		}
+		if w.End() < w.Start() {
+			return SegmentedArcs{}, errors.New("rings: inverted window")
+		}
+		if w.Start() < loc.Start() || w.End() > loc.End() {
+			return SegmentedArcs{}, errors.New("rings: window out of range")
+		}
+		total += float64(w.Len())
+	}
+
+	arc := base.Arc()
+	scale := arc.Phi * Angle((1-gap*float64(len(ws)))/total)
+	g := Angle(gap) * arc.Phi
+
+	segs := make(map[feat.Feature][]Segment, 2*len(ws))
+	theta := arc.Theta + g/2
+	for _, w := range ws {
+		phi := Angle(w.Len()) * scale
+		var s Segment
+		if o, ok := w.(feat.Orienter); ok && o.Orientation() == feat.Reverse {
+			s = Segment{Start: w.Start(), End: w.End(), Arc: Arc{Theta: Normalize(theta + phi), Phi: -phi}}
+		} else {
+			s = Segment{Start: w.Start(), End: w.End(), Arc: Arc{Theta: Normalize(theta), Phi: phi}}
+		}
+		segs[w] = []Segment{s}
+		segs[w.Location()] = append(segs[w.Location()], s)
+		theta += phi + g
+	}
+	for _, s := range segs {
+		sort.Sort(segmentsByStart(s))
+		for i := 1; i < len(s); i++ {
+			if s[i].Start < s[i-1].End {
+				return SegmentedArcs{}, errors.New("rings: overlapping windows")
+			}
+		}
+	}
+
+	return SegmentedArcs{Base: arc, Segments: segs}, nil
+}
+
+// segmentsByStart sorts a []Segment by ascending start position.
+type segmentsByStart []Segment
+
+func (s segmentsByStart) Len() int           { return len(s) }
+func (s segmentsByStart) Less(i, j int) bool { return s[i].Start < s[j].Start }
+func (s segmentsByStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Arc returns the base arc of the SegmentedArcs.
+func (a SegmentedArcs) Arc() Arc { return a.Base }
+
+// ArcOf returns the arc of a feature in the context of the provided location.
+//
+// The behaviour of ArcOf depends on the the nil status of loc and f:
+//
+//  - if both loc and f are non-nil, f must have a sub-feature relationship with loc,
+//    and the returned arc will be the arc of f.
+//  - if either of loc or f are nil, then the arc of the non-nil parameter will be
+//    returned. If the parameter is a feature held by the SegmentedArcs, the returned
+//    arc spans all its segments.
+//  - if both loc and f are nil, the base arc will be returned.
+//
+// Features that partially overlap the segments of their location are clipped to the
+// segments. If no matching feature is found a non-nil error is returned.
+func (a SegmentedArcs) ArcOf(loc, f feat.Feature) (Arc, error) {
+	var q feat.Feature
+	switch {
+	case loc != nil && f != nil:
+		if !contains(loc, f) {
+			return arcNaN, errors.New("rings: location is not parent of feature")
+		}
+		if f.Start() < loc.Start() || f.Start() > loc.End() {
+			return arcNaN, errors.New("rings: feature out of range")
+		}
+		segs, ok := a.containingSegmentsOf(loc)
+		if !ok {
+			return arcNaN, errors.New("rings: location not found")
+		}
+		return segmentArcOf(segs, f.Start(), f.End())
+	case f != nil:
+		q = f
+	case loc != nil:
+		q = loc
+	default:
+		return a.Base, nil
+	}
+	if segs, ok := a.Segments[q]; ok {
+		if len(segs) == 0 {
+			return arcNaN, errors.New("rings: no segment for feature")
+		}
+		first, last := segs[0], segs[len(segs)-1]
+		return segmentArcOf(segs, first.Start, last.End)
+	}
+	if loc := q.Location(); loc != nil {
+		return a.ArcOf(loc, q)
+	}
+	return arcNaN, errors.New("rings: location not found")
+}
+
+// segmentArcOf returns the arc spanning the positions start and end within the
+// provided segments. The span is clipped to the segments it overlaps, which must
+// be continuous.
+func segmentArcOf(segs []Segment, start, end int) (Arc, error) {
+	var i, j int
+	if start == end {
+		i = sort.Search(len(segs), func(i int) bool { return segs[i].End >= start })
+		if i == len(segs) || segs[i].Start > start {
+			return arcNaN, ErrOutsideWindows
+		}
+		j = i
+	} else {
+		i = sort.Search(len(segs), func(i int) bool { return segs[i].End > start })
+		j = sort.Search(len(segs), func(j int) bool { return segs[j].Start >= end }) - 1
+		if i == len(segs) || j < i {
+			return arcNaN, ErrOutsideWindows
+		}
+	}
+	for k := i; k < j; k++ {
+		if !segs[k].continuesTo(segs[k+1]) {
+			return arcNaN, errors.New("rings: feature spans discontinuous segments")
+		}
+	}
+	if start < segs[i].Start {
+		start = segs[i].Start
+	}
+	if end > segs[j].End {
+		end = segs[j].End
+	}
+	theta := segs[i].angleAt(start)
+	return Arc{Theta: theta, Phi: segs[j].angleAt(end) - theta}, nil
+}
+
+// continuesTo returns whether the segment t continues from the end of s, both
+// in feature coordinates and in angle.
+func (s Segment) continuesTo(t Segment) bool {
+	const tol = 1e-9
+	if s.End != t.Start || (s.Phi < 0) != (t.Phi < 0) {
+		return false
+	}
+	d := Normalize(s.Theta + s.Phi - t.Theta)
+	return d < tol || Complete-d < tol
+}
+
+// ArcsOf appends the arcs of the features in fs, each in the context of its location,
+// to dst and returns the extended slice. The segments of each location are looked up
+// only once. If any feature is not found a non-nil error is returned.
+func (a SegmentedArcs) ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error) {
+	var (
+		last     feat.Feature
+		lastSegs []Segment
+		seen     = make(map[feat.Feature][]Segment)
+	)
+	for _, f := range fs {
+		loc := f.Location()
+		if loc == nil {
+			arc, err := a.ArcOf(nil, f)
+			if err != nil {
+				return dst, err
+			}
+			dst = append(dst, arc)
+			continue
+		}
+		if loc != last {
+			segs, ok := seen[loc]
+			if !ok {
+				segs, ok = a.containingSegmentsOf(loc)
+				if !ok {
+					return dst, errors.New("rings: location not found")
+				}
+				seen[loc] = segs
+			}
+			last, lastSegs = loc, segs
+		}
+		if f.Start() < loc.Start() || f.Start() > loc.End() {
+			return dst, errors.New("rings: feature out of range")
+		}
+		arc, err := segmentArcOf(lastSegs, f.Start(), f.End())
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, arc)
+	}
+	return dst, nil
+}
+
+// PositionOf returns the feature held by the SegmentedArcs at the angle theta, and the
+// position within the feature corresponding to theta. When both a feature and one of its
+// locations are held, as is the case for display windows, the location is returned.
+// Reversed segments are taken into account. Each segment holds the angles from its start
+// up to but not including its end, so an angle on the boundary between two abutting
+// segments maps to only one of them. If more than one feature is found at theta, the
+// first by name, start and end is returned. If no feature is found at theta, a non-nil
+// error is returned.
+func (a SegmentedArcs) PositionOf(theta Angle) (f feat.Feature, pos int, err error) {
+	for q, segs := range a.Segments {
+		if q == nil || a.hasMappedLocation(q) || (f != nil && !precedes(q, f)) {
+			continue
+		}
+		for _, s := range segs {
+			if frac, ok := s.locate(theta); ok {
+				f, pos = q, s.Start+int(frac*float64(s.End-s.Start))
+				break
+			}
+		}
+	}
+	if f == nil {
+		return nil, 0, errors.New("rings: no feature at angle")
+	}
+	return f, pos, nil
+}
+
+// hasMappedLocation returns whether any location of f is held by the SegmentedArcs.
+func (a SegmentedArcs) hasMappedLocation(f feat.Feature) bool {
+	for q := f.Location(); q != nil; q = q.Location() {
+		if _, ok := a.Segments[q]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (a SegmentedArcs) containingSegmentsOf(f feat.Feature) ([]Segment, bool) {
+	for q := f; q != nil; q = q.Location() {
+		segs, ok := a.Segments[q]
+		if ok {
+			return segs, ok
+		}
+	}
+	return nil, false
+}
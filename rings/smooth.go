@@ -0,0 +1,150 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"math"
+	"sort"
+)
+
+// Smoother is a type that can smooth a score series.
+type Smoother interface {
+	// Smooth fills dst with the smoothed values of the series of values at the positions
+	// in pos. The positions are sorted in ascending order. NaN values must not contribute
+	// to the smoothed values and the smoothed value of a NaN value must be NaN.
+	Smooth(dst, pos, values []float64)
+}
+
+// window calls fn for each non-NaN value with the index of the value and the indices of
+// the values within the half-open interval [lo, hi) of positions within width/2 of the
+// value's position. The NaN values within the interval must be skipped by fn. If width
+// is not positive, values are copied to dst unaltered.
+func window(dst, pos, values []float64, width int, fn func(i, lo, hi int)) {
+	if width <= 0 {
+		copy(dst, values)
+		return
+	}
+	half := float64(width) / 2
+	var lo, hi int
+	for i, v := range values {
+		for pos[i]-pos[lo] > half {
+			lo++
+		}
+		for hi < len(pos) && pos[hi]-pos[i] <= half {
+			hi++
+		}
+		if math.IsNaN(v) {
+			dst[i] = v
+			continue
+		}
+		fn(i, lo, hi)
+	}
+}
+
+// RollingMean is a Smoother that replaces each value with the mean of the values within
+// a window of the specified width in bases centred on the value.
+type RollingMean int
+
+// Smooth fills dst with the rolling mean of values.
+func (s RollingMean) Smooth(dst, pos, values []float64) {
+	window(dst, pos, values, int(s), func(i, lo, hi int) {
+		var (
+			sum float64
+			n   int
+		)
+		for _, v := range values[lo:hi] {
+			if math.IsNaN(v) {
+				continue
+			}
+			sum += v
+			n++
+		}
+		dst[i] = sum / float64(n)
+	})
+}
+
+// RollingMedian is a Smoother that replaces each value with the median of the values within
+// a window of the specified width in bases centred on the value.
+type RollingMedian int
+
+// Smooth fills dst with the rolling median of values.
+func (s RollingMedian) Smooth(dst, pos, values []float64) {
+	var buf []float64
+	window(dst, pos, values, int(s), func(i, lo, hi int) {
+		buf = buf[:0]
+		for _, v := range values[lo:hi] {
+			if math.IsNaN(v) {
+				continue
+			}
+			buf = append(buf, v)
+		}
+		sort.Float64s(buf)
+		n := len(buf)
+		if n%2 == 1 {
+			dst[i] = buf[n/2]
+		} else {
+			dst[i] = (buf[n/2-1] + buf[n/2]) / 2
+		}
+	})
+}
+
+// Gaussian is a Smoother that replaces each value with the Gaussian weighted mean of the
+// values within a window of the specified width in bases centred on the value. The standard
+// deviation of the Gaussian kernel is one sixth of the window width.
+type Gaussian int
+
+// Smooth fills dst with the Gaussian smoothed values.
+func (s Gaussian) Smooth(dst, pos, values []float64) {
+	sigma := float64(s) / 6
+	window(dst, pos, values, int(s), func(i, lo, hi int) {
+		var sum, sw float64
+		for k, v := range values[lo:hi] {
+			if math.IsNaN(v) {
+				continue
+			}
+			d := (pos[lo+k] - pos[i]) / sigma
+			w := math.Exp(-d * d / 2)
+			sum += w * v
+			sw += w
+		}
+		dst[i] = sum / sw
+	})
+}
+
+// Loess is a Smoother that replaces each value with the value at its position of a locally
+// weighted linear regression on the values within a window of the specified width in bases
+// centred on the value. Values are weighted with the tricube function of their distance.
+type Loess int
+
+// Smooth fills dst with the locally weighted regression of values.
+func (s Loess) Smooth(dst, pos, values []float64) {
+	half := float64(s) / 2
+	window(dst, pos, values, int(s), func(i, lo, hi int) {
+		// Positions are centred on the position of the ith
+		// value, so the intercept is the regression value.
+		var sw, swx, swy, swxx, swxy float64
+		for k, v := range values[lo:hi] {
+			if math.IsNaN(v) {
+				continue
+			}
+			x := pos[lo+k] - pos[i]
+			d := math.Abs(x) / half
+			w := 1 - d*d*d
+			w *= w * w
+			sw += w
+			swx += w * x
+			swy += w * v
+			swxx += w * x * x
+			swxy += w * x * v
+		}
+		den := sw*swxx - swx*swx
+		if den == 0 {
+			dst[i] = swy / sw
+			return
+		}
+		b := (sw*swxy - swx*swy) / den
+		dst[i] = (swy - b*swx) / sw
+	})
+}
@@ -0,0 +1,37 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"gonum.org/v1/plot/vg"
+
+	"github.com/biogo/biogo/feat"
+)
+
+// SpiralArcs is an ArcOfer that lays out features along an Archimedean spiral. The
+// base arc of a SpiralArcs may sweep more than one complete turn and the arcs of its
+// features are not normalized, so that the turn holding each feature is retained.
+// Renderers based on a SpiralArcs follow the spiral, with radii specified relative
+// to the spiral at the origin of the base arc.
+//
+// Since an angle may lie on more than one turn of the spiral, the PositionOf method
+// of a SpiralArcs returns any one of the features found at the queried angle.
+type SpiralArcs struct {
+	Arcs
+
+	// Pitch is the radial distance between turns of the spiral.
+	// A negative pitch gives a spiral that turns inwards.
+	Pitch vg.Length
+}
+
+// NewSpiralArcs returns a SpiralArcs that maps the provided features along the base arc,
+// which may describe more than one turn, with fractional gaps around each feature specified
+// by gap. The radius of the spiral increases by pitch for each complete turn.
+func NewSpiralArcs(base Arcer, fs []feat.Feature, gap Gapper, pitch vg.Length) SpiralArcs {
+	return SpiralArcs{Arcs: spacedArcs(base, fs, gap, nil), Pitch: pitch}
+}
+
+// Projection returns the spiral projection of the SpiralArcs.
+func (a SpiralArcs) Projection() Projection { return spiral{base: a.Base, pitch: a.Pitch} }
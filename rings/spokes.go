@@ -36,7 +36,8 @@ type Spokes struct {
 
 // NewSpokes returns a Spokes based on the parameters, first checking that the provided features
 // are able to be rendered. An error is returned if the features are not renderable. The base of
-// a Spokes ring cannot be an Arc or a Highlight.
+// a Spokes ring cannot be an Arc or a Highlight. Features outside the display windows of base are
+// not rendered.
 func NewSpokes(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Spokes, error) {
 	if inner > outer {
 		return nil, errors.New("rings: inner radius greater than outer radius")
@@ -51,7 +52,7 @@ func NewSpokes(fs []feat.Feature, base ArcOfer, inner, outer vg.Length) (*Spokes
 		if f.Start() < f.Location().Start() || f.Start() > f.Location().End() {
 			return nil, errors.New("rings: mark out of range")
 		}
-		if _, err := base.ArcOf(nil, f); err != nil {
+		if _, err := base.ArcOf(nil, f); err != nil && err != ErrOutsideWindows {
 			return nil, err
 		}
 	}
@@ -70,6 +71,8 @@ func (r *Spokes) DrawAt(ca draw.Canvas, cen vg.Point) {
 		return
 	}
 
+	proj := projectionOf(r.Base)
+
 	var pa vg.Path
 	for _, f := range r.Set {
 		pa = pa[:0]
@@ -83,12 +86,15 @@ func (r *Spokes) DrawAt(ca draw.Canvas, cen vg.Point) {
 		}
 
 		arc, err := r.Base.ArcOf(loc, f)
+		if err == ErrOutsideWindows {
+			continue
+		}
 		if err != nil {
 			panic(fmt.Sprintf("rings: no arc for feature location: %v\n%v", err, f))
 		}
 
-		pa.Move(cen.Add(Rectangular(arc.Theta, r.Inner)))
-		pa.Line(cen.Add(Rectangular(arc.Theta, r.Outer)))
+		pa.Move(cen.Add(proj.Point(arc.Theta, r.Inner)))
+		pa.Line(cen.Add(proj.Point(arc.Theta, r.Outer)))
 
 		var sty draw.LineStyle
 		if ls, ok := f.(LineStyler); ok {
@@ -113,6 +119,22 @@ func (r *Spokes) Arc() Arc { return r.Base.Arc() }
 // the Spokes, an error is returned.
 func (r *Spokes) ArcOf(loc, f feat.Feature) (Arc, error) { return r.Base.ArcOf(loc, f) }
 
+// Projection returns the projection of the Spokes' Base.
+func (r *Spokes) Projection() Projection { return projectionOf(r.Base) }
+
+// ArcsOf appends the Arc locations of the features in fs to dst. If any feature is not
+// found in the Spokes, an error is returned.
+func (r *Spokes) ArcsOf(dst []Arc, fs []feat.Feature) ([]Arc, error) { return arcsOf(r.Base, dst, fs) }
+
+// PositionOf returns the feature and position at the angle theta in the Spokes' Base. If the
+// Base is not a PositionOfer, an error is returned.
+func (r *Spokes) PositionOf(theta Angle) (f feat.Feature, pos int, err error) {
+	if p, ok := r.Base.(PositionOfer); ok {
+		return p.PositionOf(theta)
+	}
+	return nil, 0, errors.New("rings: base cannot map angles to positions")
+}
+
 // Plot calls DrawAt using the Spokes' X and Y values as the drawing coordinates.
 func (r *Spokes) Plot(ca draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&ca)
@@ -122,11 +144,8 @@ func (r *Spokes) Plot(ca draw.Canvas, plt *plot.Plot) {
 // GlyphBoxes returns a liberal glyphbox for the blocks rendering.
 func (r *Spokes) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	return []plot.GlyphBox{{
-		X: plt.X.Norm(r.X),
-		Y: plt.Y.Norm(r.Y),
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{-r.Outer, -r.Outer},
-			Max: vg.Point{r.Outer, r.Outer},
-		},
+		X:         plt.X.Norm(r.X),
+		Y:         plt.Y.Norm(r.Y),
+		Rectangle: projectionOf(r.Base).Bounds(r.Outer),
 	}}
 }
@@ -0,0 +1,147 @@
+// Copyright ©2013 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rings
+
+import (
+	"math"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// Transform is a type that can transform score values before they are mapped to a radius
+// or color by a ScoreRenderer or an Axis. Transforms must be monotonic and must return
+// finite values for all finite scores. A nil Transform leaves values unaltered.
+type Transform interface {
+	Transform(v float64) float64
+}
+
+// inverter is a Transform that can invert its transformation.
+type inverter interface {
+	Transform
+	inverse(v float64) float64
+}
+
+// Log is a Transform that maps values to their base-10 logarithm. Values less than the
+// floor specified by the Log, including zero and negative values, are mapped to the
+// logarithm of the floor. A Log with a non-positive floor uses a floor of 1.
+type Log float64
+
+// Transform returns the base-10 logarithm of v.
+func (t Log) Transform(v float64) float64 {
+	floor := float64(t)
+	if floor <= 0 {
+		floor = 1
+	}
+	return math.Log10(math.Max(v, floor))
+}
+
+// inverse returns 10 raised to the power of v.
+func (t Log) inverse(v float64) float64 { return math.Pow(10, v) }
+
+// NegLog is a Transform that maps values to the negative of their base-10 logarithm,
+// suitable for rendering p-values. Values less than the floor specified by the NegLog,
+// including zero and negative values, are mapped to the negative logarithm of the floor.
+// A NegLog with a non-positive floor uses a floor of the smallest normal float64.
+type NegLog float64
+
+// Transform returns the negative base-10 logarithm of v.
+func (t NegLog) Transform(v float64) float64 {
+	floor := float64(t)
+	if floor <= 0 {
+		floor = 0x1p-1022
+	}
+	return -math.Log10(math.Max(v, floor))
+}
+
+// inverse returns 10 raised to the power of -v.
+func (t NegLog) inverse(v float64) float64 { return math.Pow(10, -v) }
+
+// Sqrt is a Transform that maps values to their square root, retaining the sign of
+// negative values.
+type Sqrt struct{}
+
+// Transform returns the signed square root of v.
+func (Sqrt) Transform(v float64) float64 {
+	return math.Copysign(math.Sqrt(math.Abs(v)), v)
+}
+
+// inverse returns the signed square of v.
+func (Sqrt) inverse(v float64) float64 { return math.Copysign(v*v, v) }
+
+// Symlog is a Transform that maps values to a symmetric logarithm that is approximately
+// linear for values with magnitudes less than the constant specified by the Symlog, and
+// logarithmic beyond that. A Symlog with a non-positive constant uses a constant of 1.
+type Symlog float64
+
+// Transform returns the symmetric logarithm of v.
+func (t Symlog) Transform(v float64) float64 {
+	c := float64(t)
+	if c <= 0 {
+		c = 1
+	}
+	return math.Copysign(math.Log10(1+math.Abs(v)/c), v)
+}
+
+// inverse returns the value whose symmetric logarithm is v.
+func (t Symlog) inverse(v float64) float64 {
+	c := float64(t)
+	if c <= 0 {
+		c = 1
+	}
+	return math.Copysign(c*(math.Pow(10, math.Abs(v))-1), v)
+}
+
+// TransformFunc is a Transform that applies the function to values.
+type TransformFunc func(float64) float64
+
+// Transform returns the value of the function at v.
+func (fn TransformFunc) Transform(v float64) float64 { return fn(v) }
+
+// valueScale maps score values within a range to radii or palette indices according
+// to a Transform. If inward is true, radii increase from outer toward inner as values
+// increase.
+type valueScale struct {
+	t      Transform
+	lo, hi float64
+	inward bool
+}
+
+// newValueScale returns a valueScale for the score range from min to max transformed by t.
+func newValueScale(t Transform, min, max float64) valueScale {
+	s := valueScale{t: t, lo: min, hi: max}
+	s.lo = s.transform(min)
+	s.hi = s.transform(max)
+	if s.lo > s.hi {
+		// Decreasing transforms map the range in reverse.
+		s.lo, s.hi = s.hi, s.lo
+	}
+	return s
+}
+
+// transform returns v transformed by the scale's Transform.
+func (s valueScale) transform(v float64) float64 {
+	if s.t == nil {
+		return v
+	}
+	return s.t.Transform(v)
+}
+
+// factor returns the scaling from transformed values to the interval [0, n]. If the
+// transformed range is empty, the returned factor is zero.
+func (s valueScale) factor(n float64) float64 {
+	if s.hi == s.lo {
+		return 0
+	}
+	return n / (s.hi - s.lo)
+}
+
+// radius returns the radius of v between inner and outer.
+func (s valueScale) radius(v float64, inner, outer vg.Length) vg.Length {
+	r := vg.Length((s.transform(v) - s.lo) * s.factor(float64(outer-inner)))
+	if s.inward {
+		return outer - r
+	}
+	return r + inner
+}
@@ -36,6 +36,13 @@ func (c *canvas) append(actions ...interface{}) {
 	c.actions = append(c.base, actions...)
 }
 
+// mv, pt and cl are move, line and close path components
+// for building expected paths.
+func mv(x, y vg.Length) vg.PathComp { return vg.PathComp{Type: vg.MoveComp, Pos: vg.Point{X: x, Y: y}} }
+func pt(x, y vg.Length) vg.PathComp { return vg.PathComp{Type: vg.LineComp, Pos: vg.Point{X: x, Y: y}} }
+
+var cl = vg.PathComp{Type: vg.CloseComp}
+
 type setWidth struct {
 	w vg.Length
 }